@@ -0,0 +1,61 @@
+// Package analyzer defines an Analyzer that reports lambdas
+// passed to Queryable which cannot be translated to SQL.
+//
+// It runs the same parsing as the generator, so problems are
+// shown by editors and `go vet` before `go generate` is run:
+//
+//	go vet -vettool=$(which goquery-vet) ./...
+package analyzer
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/ffenix113/goquery/internal"
+)
+
+const goqueryPath = "github.com/ffenix113/goquery"
+
+var Analyzer = &analysis.Analyzer{
+	Name: "goquery",
+	Doc:  "report lambdas passed to goquery Queryable that cannot be translated to SQL",
+	URL:  "https://pkg.go.dev/github.com/ffenix113/goquery/analyzer",
+	Run:  run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	if !importsGoquery(pass) {
+		return nil, nil
+	}
+
+	c := internal.Context{
+		FileSet:  pass.Fset,
+		TypeInfo: pass.TypesInfo,
+		Files:    pass.Files,
+		Data:     map[string]internal.EntityCalls{},
+		Report: func(diagnostic internal.Diagnostic) {
+			pass.Report(analysis.Diagnostic{
+				Pos:     diagnostic.Pos,
+				End:     diagnostic.End,
+				Message: diagnostic.Message,
+			})
+		},
+	}
+
+	for _, file := range pass.Files {
+		ast.Walk(&c, file)
+	}
+
+	return nil, nil
+}
+
+func importsGoquery(pass *analysis.Pass) bool {
+	for _, imported := range pass.Pkg.Imports() {
+		if imported.Path() == goqueryPath {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,65 @@
+package analyzer_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/ffenix113/goquery/analyzer"
+)
+
+func TestAnalyzer(t *testing.T) {
+	file, err := filepath.Abs("testdata/vet/vet.go")
+	require.NoError(t, err)
+
+	want := []string{
+		file + `:15:55: argument is not provided: name`,
+		file + `:16:47: function strconv.Itoa is not supported`,
+		file + `:25:52: function IsRoot cannot be inlined, as it calls itself`,
+	}
+
+	t.Run("resolved objects", func(t *testing.T) {
+		assert.ElementsMatch(t, want, analyze(t, nil))
+	})
+
+	// gopls parses files without resolving objects
+	// of identifiers, so ast.Ident.Obj is not set.
+	t.Run("unresolved objects", func(t *testing.T) {
+		parseFile := func(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
+			return parser.ParseFile(fset, filename, src, parser.AllErrors|parser.ParseComments|parser.SkipObjectResolution)
+		}
+
+		assert.ElementsMatch(t, want, analyze(t, parseFile))
+	})
+}
+
+func analyze(t *testing.T, parseFile func(fset *token.FileSet, filename string, src []byte) (*ast.File, error)) []string {
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes |
+			packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps | packages.NeedTypesSizes,
+		ParseFile: parseFile,
+	}, "./testdata/vet")
+	require.NoError(t, err)
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{analyzer.Analyzer}, pkgs, nil)
+	require.NoError(t, err)
+
+	var diagnostics []string
+	for _, root := range graph.Roots {
+		require.NoError(t, root.Err)
+
+		for _, diagnostic := range root.Diagnostics {
+			diagnostics = append(diagnostics, root.Package.Fset.Position(diagnostic.Pos).String()+": "+diagnostic.Message)
+		}
+	}
+
+	return diagnostics
+}
@@ -0,0 +1,36 @@
+package vet
+
+import (
+	"strconv"
+
+	"github.com/ffenix113/goquery"
+)
+
+type User struct {
+	ID   int64
+	Name string
+}
+
+func Filters[T any](q goquery.Queryable[*User], name string) goquery.Queryable[*User] {
+	return q.Where(func(u *User) bool { return u.Name == name }).
+		Where(func(u *User) bool { return u.Name == strconv.Itoa(1) }).
+		Where(func(u *User) bool { return u.ID > 1 }).
+		Where(func(u *User) bool { return u.Name == name }, name).
+		Where(func(u *User) bool { return u.IsNamed() }).
+		Where(func(u *User) bool { return u.IsRoot() })
+}
+
+func (u *User) IsNamed() bool { return u.Name != "" }
+
+func (u *User) IsRoot() bool { return u.ID == 0 || u.IsRoot() }
+
+func Declared(q goquery.Queryable[*User]) goquery.Queryable[*User] {
+	const minID = 10
+	named := func(u *User) bool { return u.Name != "" }
+
+	return q.Where(isAdmin).
+		Where(named).
+		Where(func(u *User) bool { return u.ID > minID && true })
+}
+
+func isAdmin(u *User) bool { return u.ID == 1 }
@@ -0,0 +1,28 @@
+package goquery
+
+// callSites counts calls of the same kind made from
+// the same line, to tell apart chained calls on it.
+//
+// Chained calls are always evaluated in the same order,
+// so n-th call from the line is the n-th generated one,
+// modulo number of them if the line is evaluated again.
+type callSites map[callSite]int
+
+type callSite struct {
+	field  string
+	caller Caller
+}
+
+// resolve sets index of the call on the line to the caller.
+func (s callSites) resolve(calls Calls, field string, caller Caller) Caller {
+	lineCalls := calls.lineCalls[callSite{field: field, caller: caller}]
+	if lineCalls == 0 {
+		return caller
+	}
+
+	site := callSite{field: field, caller: caller}
+	caller.Index = s[site] % lineCalls
+	s[site]++
+
+	return caller
+}
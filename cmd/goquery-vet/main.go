@@ -0,0 +1,14 @@
+// goquery-vet reports lambdas passed to goquery Queryable
+// that cannot be translated to SQL. It can be used
+// as a vet tool: `go vet -vettool=$(which goquery-vet) ./...`.
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/ffenix113/goquery/analyzer"
+)
+
+func main() {
+	singlechecker.Main(analyzer.Analyzer)
+}
@@ -1,37 +1,80 @@
 package main
 
 import (
-	"go/ast"
-	"go/token"
+	"flag"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/ffenix113/goquery/internal"
 )
 
 func main() {
-	file := os.Getenv("GOFILE")
-	if file == "" {
-		file = os.Args[1]
+	if len(os.Args) > 1 && os.Args[1] == "check" {
+		check(os.Args[2:])
+		return
 	}
 
+	perPackage := flag.Bool("per-package", false, "generate single file per package instead of one per source file")
+	flag.Parse()
+
+	args := flag.Args()
+
 	var err error
-	file, err = filepath.Abs(file)
-	if err != nil {
-		panic(err)
+
+	switch {
+	case len(args) == 1 && strings.HasSuffix(args[0], ".go"):
+		err = generateFile(args[0])
+	case len(args) != 0:
+		err = internal.GeneratePackages(args, *perPackage)
+	case os.Getenv("GOFILE") != "" && !*perPackage:
+		// Invoked by `go generate` without arguments.
+		err = generateFile(os.Getenv("GOFILE"))
+	default:
+		err = internal.GeneratePackages([]string{"."}, *perPackage)
 	}
 
-	c := internal.Context{
-		Data: map[string]map[token.Position]internal.QueryData{},
+	exitOnError(err)
+}
+
+func generateFile(file string) error {
+	file, err := filepath.Abs(file)
+	if err != nil {
+		return err
 	}
 
-	if err := c.ParseFile(file); err != nil {
-		panic(err)
+	return internal.GenerateFile(file)
+}
+
+// check exits with non-zero status if generated
+// files of the packages are not up to date.
+func check(args []string) {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	perPackage := flags.Bool("per-package", false, "files are generated per package instead of per source file")
+	_ = flags.Parse(args)
+
+	patterns := flags.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
 	}
 
-	// ast.Print(c.FileSet, c.AstFile)
+	stale, err := internal.CheckPackages(os.Stderr, patterns, *perPackage)
+	exitOnError(err)
 
-	ast.Walk(&c, c.AstFile)
+	if stale != 0 {
+		fmt.Fprintf(os.Stderr, "%d generated files are not up to date, run `go generate`\n", stale)
+		os.Exit(1)
+	}
+}
+
+// exitOnError prints the error, i.e. all diagnostics
+// of the generation, and exits with status 1.
+func exitOnError(err error) {
+	if err == nil {
+		return
+	}
 
-	internal.WriteBase(&c, file)
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
 }
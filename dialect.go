@@ -0,0 +1,45 @@
+package goquery
+
+import (
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/schema"
+)
+
+// DO NOT USE: this is only for generated code!
+//
+// DialectQuery returns variant of the query for the
+// dialect of q, or defaultQuery if it has no variant.
+func DialectQuery(q any, defaultQuery string, queries map[string]string) string {
+	if query, ok := queries[dialectName(q).String()]; ok {
+		return query
+	}
+
+	return defaultQuery
+}
+
+// DO NOT USE: this is only for generated code!
+//
+// DialectArgs returns variant of the query arguments for
+// the dialect of q, or defaultArgs if it has no variant.
+func DialectArgs(q any, defaultArgs []any, args map[string][]any) []any {
+	if dialectArgs, ok := args[dialectName(q).String()]; ok {
+		return dialectArgs
+	}
+
+	return defaultArgs
+}
+
+// dialectName returns name of the dialect of the query.
+func dialectName(q any) dialect.Name {
+	if builder, ok := q.(bun.QueryBuilder); ok {
+		q = builder.Unwrap()
+	}
+
+	query, ok := q.(interface{ Dialect() schema.Dialect })
+	if !ok {
+		return dialect.Invalid
+	}
+
+	return query.Dialect().Name()
+}
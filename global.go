@@ -2,13 +2,22 @@ package goquery
 
 import (
 	"fmt"
+	"maps"
+	"path"
 	"reflect"
+	"runtime"
+	"slices"
+	"unsafe"
 
 	"github.com/uptrace/bun"
 )
 
 var globalCallsMap = map[reflect.Type]Calls{}
 
+// packagePaths maps directories of the packages, as they
+// are reported by runtime.Caller, to their module-relative paths.
+var packagePaths = map[string]string{}
+
 type Factory[T any] interface {
 	// New creates new Queryable.
 	//
@@ -18,10 +27,7 @@ type Factory[T any] interface {
 }
 
 func NewFactory[T any](db *bun.DB, helper ...Helper) Factory[T] {
-	var t T
-	if tp := reflect.TypeOf(t); tp.Kind() != reflect.Pointer || tp.Elem().Kind() != reflect.Struct {
-		panic(fmt.Sprintf("input type argument must be a pointer to a struct, but got %T", t))
-	}
+	checkEntityType[T]()
 
 	var selectedHelper Helper
 	if len(helper) > 0 {
@@ -37,26 +43,118 @@ func NewFactory[T any](db *bun.DB, helper ...Helper) Factory[T] {
 	}
 }
 
+func checkEntityType[T any]() {
+	var t T
+	if tp := reflect.TypeOf(t); tp.Kind() != reflect.Pointer || tp.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("input type argument must be a pointer to a struct, but got %T", t))
+	}
+}
+
 // DO NOT USE: this is only for generated code!
 func AddToGlobalEntity[T any](callsMap Calls) {
-	var argType T
-	typeArg := reflect.TypeOf(argType)
-	if _, ok := globalCallsMap[typeArg]; !ok {
-		globalCallsMap[typeArg] = callsMap
+	typeArg := entityType[T]()
+
+	calls := globalCallsMap[typeArg]
+	if calls.lineCalls == nil {
+		calls.lineCalls = map[callSite]int{}
+	}
+
+	calls.Where = mergeCallers(calls.lineCalls, "Where", calls.Where, callsMap.Where)
+	calls.OrderBy = mergeCallers(calls.lineCalls, "OrderBy", calls.OrderBy, callsMap.OrderBy)
+	calls.Select = mergeCallers(calls.lineCalls, "Select", calls.Select, callsMap.Select)
+	calls.GroupBy = mergeCallers(calls.lineCalls, "GroupBy", calls.GroupBy, callsMap.GroupBy)
+	calls.Include = mergeCallers(calls.lineCalls, "Include", calls.Include, callsMap.Include)
+	calls.Update = mergeCallers(calls.lineCalls, "Update", calls.Update, callsMap.Update)
+
+	for field, captures := range callsMap.Captures {
+		if calls.Captures == nil {
+			calls.Captures = map[string]map[Caller]CaptureFunc{}
+		}
+
+		if calls.Captures[field] == nil {
+			calls.Captures[field] = map[Caller]CaptureFunc{}
+		}
+
+		maps.Copy(calls.Captures[field], captures)
+	}
+
+	globalCallsMap[typeArg] = calls
+}
+
+// DO NOT USE: this is only for generated code!
+//
+// RegisterPackagePath registers module-relative path of the
+// package directory generated file is placed in. Directory
+// is taken from runtime.Caller, so it is the same as for
+// the callers in the package regardless of where the
+// module is built or whether `-trimpath` is used.
+func RegisterPackagePath(packagePath string) {
+	_, file, _, ok := runtime.Caller(1)
+	if !ok {
+		panic("cannot get caller of RegisterPackagePath")
+	}
+
+	packagePaths[path.Dir(file)] = packagePath
+}
+
+// DO NOT USE: this is only for generated code!
+//
+// Closure returns pointer to the closure of the function,
+// which holds variables captured by it after the pointer
+// to the code of the function.
+func Closure(fn any) unsafe.Pointer {
+	if reflect.TypeOf(fn).Kind() != reflect.Func {
+		panic(fmt.Sprintf("closure of %T is requested", fn))
+	}
 
-		return
+	// Function value is a pointer to the closure,
+	// and interface holds pointers as they are.
+	return (*[2]unsafe.Pointer)(unsafe.Pointer(&fn))[1]
+}
+
+// captured returns arguments of the call with values of
+// the local variables captured by its lambda fn appended.
+func (c Calls) captured(field string, caller Caller, fn any, args []any) []any {
+	capture, ok := c.Captures[field][caller]
+	if !ok {
+		return args
 	}
 
-	callers := getCallMapFromGlobal[T]()
+	return append(slices.Clip(args), capture(fn)...)
+}
 
-	for caller, queryFunc := range callsMap.Where {
-		callers.Where[caller] = queryFunc
+// callerFile returns module-relative path of the file
+// reported by runtime.Caller, as it is used in generated code.
+func callerFile(file string) string {
+	if packagePath, ok := packagePaths[path.Dir(file)]; ok {
+		return packagePath + "/" + path.Base(file)
 	}
+
+	return file
+}
+
+func mergeCallers[F any](lineCalls map[callSite]int, field string, dst, src map[Caller]F) map[Caller]F {
+	if dst == nil {
+		dst = make(map[Caller]F, len(src))
+	}
+
+	for caller, f := range src {
+		dst[caller] = f
+
+		if caller.Index == 0 {
+			continue
+		}
+
+		// Calls of the line are merged in any order.
+		line := callSite{field: field, caller: Caller{File: caller.File, Line: caller.Line}}
+		lineCalls[line] = max(lineCalls[line], caller.Index+1)
+	}
+
+	return dst
 }
 
 func getCallMapFromGlobal[T any]() Calls {
-	var argType T
-	typeArg := reflect.TypeOf(argType)
+	typeArg := entityType[T]()
 
 	callMap, ok := globalCallsMap[typeArg]
 	if !ok {
@@ -65,3 +163,15 @@ func getCallMapFromGlobal[T any]() Calls {
 
 	return callMap
 }
+
+// entityType returns type of the entity T
+// without pointers, as functions are generated
+// for the entity regardless of pointers to it.
+func entityType[T any]() reflect.Type {
+	tp := reflect.TypeOf((*T)(nil)).Elem()
+	for tp.Kind() == reflect.Pointer {
+		tp = tp.Elem()
+	}
+
+	return tp
+}
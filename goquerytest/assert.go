@@ -0,0 +1,174 @@
+package goquerytest
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/ffenix113/goquery"
+)
+
+// Update makes AssertGolden write golden files instead of
+// comparing them. It is set when GOQUERYTEST_UPDATE
+// environment variable is not empty.
+//
+// Golden files are also written when tests are run with
+// -update flag, if the tests define it or call RegisterUpdateFlag.
+var Update = os.Getenv("GOQUERYTEST_UPDATE") != ""
+
+// RegisterUpdateFlag defines -update flag which sets Update.
+// goquerytest does not define the flag by itself, as it would
+// conflict with the flag of the same name defined by tests or
+// other packages, so the flag is not defined if it already exists.
+//
+// It must be called before flags are parsed, i.e. from init:
+//
+//	func init() {
+//		goquerytest.RegisterUpdateFlag()
+//	}
+func RegisterUpdateFlag() {
+	if flag.Lookup("update") != nil {
+		return
+	}
+
+	flag.BoolVar(&Update, "update", Update, "write golden files of goquerytest.AssertGolden")
+}
+
+// updating reports whether golden files are written.
+func updating() bool {
+	if Update {
+		return true
+	}
+
+	updateFlag := flag.Lookup("update")
+	if updateFlag == nil {
+		return false
+	}
+
+	getter, ok := updateFlag.Value.(flag.Getter)
+	if !ok {
+		return false
+	}
+
+	update, ok := getter.Get().(bool)
+
+	return ok && update
+}
+
+// SQL returns the query and its args as they
+// would be sent to the database by bun.
+//
+// Query of the Queryable is used, so the query does not
+// have columns or table unless it has the model set, i.e.
+// with factory.New(db.NewSelect().Model((*User)(nil))).
+// Queryable of goquery.NewMemoryFactory does not have SQL.
+func SQL[T any](q goquery.Queryable[T]) (string, []any, error) {
+	var conn Conn
+	if _, err := q.Query().Conn(&conn).Exec(context.Background()); err != nil {
+		return "", nil, err
+	}
+
+	return conn.Query, conn.Args, nil
+}
+
+// AssertSQL reports an error to t if SQL
+// of the query is not wantSQL with wantArgs.
+func AssertSQL[T any](t testing.TB, q goquery.Queryable[T], wantSQL string, wantArgs ...any) bool {
+	t.Helper()
+
+	sql, args, err := SQL(q)
+	if err != nil {
+		t.Errorf("goquerytest: %s", err)
+		return false
+	}
+
+	ok := true
+	if sql != wantSQL {
+		t.Errorf("goquerytest: unexpected SQL\n\tgot:  %s\n\twant: %s", sql, wantSQL)
+		ok = false
+	}
+
+	if len(args) != 0 || len(wantArgs) != 0 {
+		if !reflect.DeepEqual(args, wantArgs) {
+			t.Errorf("goquerytest: unexpected args\n\tgot:  %#v\n\twant: %#v", args, wantArgs)
+			ok = false
+		}
+	}
+
+	return ok
+}
+
+// AssertGolden reports an error to t if SQL of the
+// query and its args differ from the golden file.
+//
+// Golden files are written, instead of compared, if Update
+// is set or tests are run with -update flag, see RegisterUpdateFlag:
+//
+//	GOQUERYTEST_UPDATE=1 go test ./...
+func AssertGolden[T any](t testing.TB, q goquery.Queryable[T], path string) bool {
+	t.Helper()
+
+	sql, args, err := SQL(q)
+	if err != nil {
+		t.Errorf("goquerytest: %s", err)
+		return false
+	}
+
+	got := golden(sql, args)
+
+	if updating() {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Errorf("goquerytest: %s", err)
+			return false
+		}
+
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Errorf("goquerytest: %s", err)
+			return false
+		}
+
+		return true
+	}
+
+	want, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		t.Errorf("goquerytest: golden file %s does not exist, run tests with GOQUERYTEST_UPDATE=1 to create it", path)
+		return false
+	case err != nil:
+		t.Errorf("goquerytest: %s", err)
+		return false
+	case string(want) == got:
+		return true
+	}
+
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(want)),
+		B:        difflib.SplitLines(got),
+		FromFile: path,
+		ToFile:   "actual",
+		Context:  3,
+	})
+	t.Errorf("goquerytest: SQL differs from golden file, run tests with GOQUERYTEST_UPDATE=1 to update it\n%s", diff)
+
+	return false
+}
+
+// golden returns content of the golden file:
+// the query, and args each on a separate line.
+func golden(sql string, args []any) string {
+	res := sql + "\n"
+	for _, arg := range args {
+		res += fmt.Sprintf("-- arg: %#v\n", arg)
+	}
+
+	return res
+}
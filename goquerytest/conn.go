@@ -0,0 +1,29 @@
+package goquerytest
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/uptrace/bun"
+)
+
+// Conn is bun.IConn that keeps the query
+// passed to ExecContext instead of executing it.
+//
+//	var conn goquerytest.Conn
+//	_, err := q.Query().Conn(&conn).Exec(ctx)
+//
+// Other methods of bun.IConn are not implemented.
+type Conn struct {
+	bun.IConn
+	// Query and Args are the last executed query.
+	Query string
+	Args  []any
+}
+
+func (c *Conn) ExecContext(_ context.Context, query string, args ...any) (sql.Result, error) {
+	c.Query = query
+	c.Args = args
+
+	return nil, nil
+}
@@ -0,0 +1,142 @@
+// Package goquerytest helps to test that queries
+// generated by goquery mean the same as their lambdas.
+package goquerytest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+
+	"github.com/ffenix113/goquery"
+)
+
+// Mismatch is a fixture row that is selected by
+// the query only in SQLite or only in Go.
+type Mismatch[T any] struct {
+	Row T
+	// InSQL reports whether the row is selected by the query in SQLite,
+	// InGo reports whether it is selected when lambdas are called in Go.
+	InSQL, InGo bool
+	// SQL and Args are the query sent to the database.
+	SQL  string
+	Args []any
+}
+
+func (m Mismatch[T]) String() string {
+	return fmt.Sprintf("row %+v is selected in SQL: %t, in Go: %t\n\tquery: %s\n\targs: %v", m.Row, m.InSQL, m.InGo, m.SQL, m.Args)
+}
+
+// Diff runs the query for each of the rows both in SQLite
+// through bun and as plain Go with goquery.NewMemoryFactory,
+// and returns rows for which the results differ.
+//
+// query is called for each row and each of the evaluations,
+// it should only add filters, ordering and paging to the
+// provided Queryable. Rows are evaluated one at a time,
+// so paging applies to the single row.
+//
+// db must use SQLite dialect. Table of T is created
+// in it for the time of Diff, so it must not exist.
+func Diff[T any](ctx context.Context, db *bun.DB, rows []T, query func(q goquery.Queryable[T]) goquery.Queryable[T]) ([]Mismatch[T], error) {
+	if name := db.Dialect().Name(); name != dialect.SQLite {
+		return nil, fmt.Errorf("goquerytest: database must use sqlite dialect, got %s", name)
+	}
+
+	var model T
+	if _, err := db.NewCreateTable().Model(model).Exec(ctx); err != nil {
+		return nil, err
+	}
+	defer func() { _, _ = db.NewDropTable().Model(model).Exec(ctx) }()
+
+	factory := goquery.NewFactory[T](db)
+
+	var mismatches []Mismatch[T]
+	for _, row := range rows {
+		inSQL, stored, err := selectedInSQL(ctx, db, factory, row, query)
+		if err != nil {
+			return nil, err
+		}
+
+		// Row is evaluated in Go as it is selected back
+		// from the database, so it has the values set by
+		// the database, i.e. times with dropped monotonic clock.
+		inGo, err := selected(ctx, goquery.NewMemoryFactory([]T{stored}), query)
+		if err != nil {
+			return nil, err
+		}
+
+		if inSQL == inGo {
+			continue
+		}
+
+		sql, args, err := SQL(query(factory.New(db.NewSelect().Model(model))))
+		if err != nil {
+			return nil, err
+		}
+
+		mismatches = append(mismatches, Mismatch[T]{
+			Row:   row,
+			InSQL: inSQL,
+			InGo:  inGo,
+			SQL:   sql,
+			Args:  args,
+		})
+	}
+
+	return mismatches, nil
+}
+
+// Compare is the same as Diff, but reports
+// the error and each of the mismatches to t.
+func Compare[T any](t testing.TB, db *bun.DB, rows []T, query func(q goquery.Queryable[T]) goquery.Queryable[T]) {
+	t.Helper()
+
+	mismatches, err := Diff(context.Background(), db, rows, query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mismatch := range mismatches {
+		t.Error(mismatch)
+	}
+}
+
+// selectedInSQL reports whether the query selects the row when
+// it is the only row in the table, and returns the stored row.
+func selectedInSQL[T any](ctx context.Context, db *bun.DB, factory goquery.Factory[T], row T, query func(q goquery.Queryable[T]) goquery.Queryable[T]) (bool, T, error) {
+	var model T
+	if _, err := db.NewTruncateTable().Model(model).Exec(ctx); err != nil {
+		return false, model, err
+	}
+
+	if _, err := db.NewInsert().Model(row).Exec(ctx); err != nil {
+		return false, model, err
+	}
+
+	stored := reflect.New(reflect.TypeOf(model).Elem()).Interface().(T)
+
+	// Soft deleted row is selected too,
+	// as it is the row from the fixture.
+	selectQuery := db.NewSelect().Model(stored)
+	if db.Table(reflect.TypeOf(model)).SoftDeleteField != nil {
+		selectQuery = selectQuery.WhereAllWithDeleted()
+	}
+
+	if err := selectQuery.Scan(ctx); err != nil {
+		return false, model, err
+	}
+
+	inSQL, err := selected(ctx, factory, query)
+
+	return inSQL, stored, err
+}
+
+func selected[T any](ctx context.Context, factory goquery.Factory[T], query func(q goquery.Queryable[T]) goquery.Queryable[T]) (bool, error) {
+	rows, err := query(factory.New()).ToSlice(ctx)
+
+	return len(rows) != 0, err
+}
@@ -0,0 +1,190 @@
+package goquery
+
+import (
+	"reflect"
+
+	"github.com/uptrace/bun/schema"
+)
+
+// Group is a group of rows that share the same key.
+//
+// Methods of the Group are translated to SQL aggregate
+// functions when used in lambdas of grouped Queryable.
+type Group[K, T any] struct {
+	// Key is the value rows are grouped by.
+	Key K
+
+	rows []T
+}
+
+// Count returns number of rows in the group.
+func (g Group[K, T]) Count() int {
+	return len(g.rows)
+}
+
+// Sum returns sum of values selected from group rows.
+func (g Group[K, T]) Sum(selector func(val T) float64) float64 {
+	var sum float64
+	for _, row := range g.rows {
+		sum += selector(row)
+	}
+
+	return sum
+}
+
+// Min returns minimal value selected from group rows.
+func (g Group[K, T]) Min(selector func(val T) float64) float64 {
+	return g.reduce(selector, func(res, val float64) bool { return val < res })
+}
+
+// Max returns maximal value selected from group rows.
+func (g Group[K, T]) Max(selector func(val T) float64) float64 {
+	return g.reduce(selector, func(res, val float64) bool { return val > res })
+}
+
+// Avg returns average of values selected from group rows.
+func (g Group[K, T]) Avg(selector func(val T) float64) float64 {
+	if len(g.rows) == 0 {
+		return 0
+	}
+
+	return g.Sum(selector) / float64(len(g.rows))
+}
+
+func (g Group[K, T]) reduce(selector func(val T) float64, replace func(res, val float64) bool) float64 {
+	var res float64
+	for i, row := range g.rows {
+		if val := selector(row); i == 0 || replace(res, val) {
+			res = val
+		}
+	}
+
+	return res
+}
+
+// GroupBy groups rows of the query by the key
+// returned from keySelector.
+//
+// Resulting Queryable should be used with Select
+// to get the key and aggregates of the groups:
+//
+//	goquery.Select(goquery.GroupBy(q, func(o *Order) string { return o.Country }),
+//		func(g goquery.Group[string, *Order]) CountryStats {
+//			return CountryStats{Country: g.Key, Orders: g.Count()}
+//		})
+//
+// Where on resulting Queryable filters groups
+// with HAVING instead of WHERE.
+//
+// The query is modified and should only be used
+// through the returned Queryable afterwards.
+func GroupBy[T, K any](q Queryable[T], keySelector func(val T) K, args ...any) Queryable[Group[K, T]] {
+	if source, ok := q.(*memoryQueryable[T]); ok {
+		return groupMemory(source, keySelector)
+	}
+
+	caller := getCaller()
+
+	source, ok := q.(*queryable[T])
+	if !ok {
+		panic("GroupBy is not supported for " + reflect.TypeOf(q).String())
+	}
+
+	caller = source.sites.resolve(source.callsMap, "GroupBy", caller)
+
+	groupBy, ok := source.callsMap.GroupBy[caller]
+	if !ok {
+		panicNotGenerated("GroupBy", caller)
+	}
+
+	if !source.projected {
+		var model T
+		source.selectQuery.Model(model)
+	}
+
+	key, keyArgs := groupBy(source.helper, source.selectQuery, source.callsMap.captured("GroupBy", caller, keySelector, args)...)
+	source.selectQuery.GroupExpr(key, keyArgs...)
+
+	return &queryable[Group[K, T]]{
+		// Generated functions for grouped queryable
+		// are defined for the type of the rows.
+		callsMap: source.callsMap,
+		helper: groupHelper{
+			Helper: source.helper,
+			key:    schema.SafeQuery(key, keyArgs),
+		},
+		db:          source.db,
+		selectQuery: source.selectQuery,
+		projected:   true,
+		sites:       source.sites,
+	}
+}
+
+// groupMemory groups filtered rows of the memory Queryable.
+//
+// As in SQL, paging of the query is applied to the groups.
+// Groups are in order of the first row of each of them.
+func groupMemory[T, K any](source *memoryQueryable[T], keySelector func(val T) K) Queryable[Group[K, T]] {
+	return &memoryQueryable[Group[K, T]]{
+		source: func() ([]Group[K, T], error) {
+			rows, err := source.filtered(nil)
+			if err != nil {
+				return nil, err
+			}
+
+			var groups []Group[K, T]
+			indexes := map[any]int{}
+
+			for _, row := range rows {
+				key := keySelector(row)
+
+				i, ok := groupIndex(groups, indexes, key)
+				if !ok {
+					i = len(groups)
+					groups = append(groups, Group[K, T]{Key: key})
+
+					if reflect.ValueOf(key).Comparable() {
+						indexes[key] = i
+					}
+				}
+
+				groups[i].rows = append(groups[i].rows, row)
+			}
+
+			return groups, nil
+		},
+		offset: source.offset,
+		limit:  source.limit,
+	}
+}
+
+// groupIndex returns index of the group with the key.
+//
+// Keys that cannot be used in map, like slices or structs
+// with them, are compared with other groups by their values.
+func groupIndex[T, K any](groups []Group[K, T], indexes map[any]int, key K) (int, bool) {
+	if reflect.ValueOf(key).Comparable() {
+		i, ok := indexes[key]
+
+		return i, ok
+	}
+
+	for i, group := range groups {
+		if reflect.DeepEqual(group.Key, key) {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// groupHelper is a Helper of grouped Queryable.
+type groupHelper struct {
+	Helper
+	key schema.QueryWithArgs
+}
+
+// DO NOT USE: this is only for generated code!
+func GroupKey(h Helper) schema.QueryAppender {
+	return h.(groupHelper).key
+}
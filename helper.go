@@ -4,12 +4,21 @@ import (
 	"reflect"
 
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
 )
 
 var _ Helper = bunHelper{}
 
 type bunHelper struct {
+	table    *schema.Table
 	fieldMap map[string]string
+	// alias is set if column names
+	// should be qualified with it.
+	alias string
+	// joins are aliases of relations joined to the query.
+	// It is set only for helpers of a single query,
+	// and shared with helpers of joined relations.
+	joins map[string]bool
 }
 
 func NewBunHelper[T any](db *bun.DB) Helper {
@@ -18,15 +27,17 @@ func NewBunHelper[T any](db *bun.DB) Helper {
 	tables := db.Dialect().Tables()
 	tables.Register(&t)
 
-	table := tables.Get(reflect.TypeOf(t))
+	return newBunHelper(tables.Get(reflect.TypeOf(t)))
+}
 
+func newBunHelper(table *schema.Table) bunHelper {
 	fieldMap := make(map[string]string)
 
 	for sqlColumnName, structField := range table.FieldMap {
 		fieldMap[structField.GoName] = sqlColumnName
 	}
 
-	return bunHelper{fieldMap: fieldMap}
+	return bunHelper{table: table, fieldMap: fieldMap}
 }
 
 func (b bunHelper) ColumnName(name string) string {
@@ -35,5 +46,86 @@ func (b bunHelper) ColumnName(name string) string {
 		panic("unknown field name: " + name)
 	}
 
+	if b.alias != "" {
+		return b.alias + "." + columnName
+	}
+
 	return columnName
 }
+
+// tableAlias returns alias of the table in the query.
+func (b bunHelper) tableAlias() string {
+	if b.alias != "" {
+		return b.alias
+	}
+
+	return b.table.Alias
+}
+
+// scopedHelper returns copy of the helper which tracks
+// relations joined to the query separately from the original.
+//
+// Relations already joined with the original
+// are tracked by the copy if inherit is set.
+func scopedHelper(h Helper, inherit bool) Helper {
+	base, ok := h.(bunHelper)
+	if !ok {
+		return h
+	}
+
+	joins := make(map[string]bool)
+	if inherit {
+		for alias := range base.joins {
+			joins[alias] = true
+		}
+	}
+
+	base.joins = joins
+
+	return base
+}
+
+// asBunHelper returns underlying bunHelper of the helper.
+func asBunHelper(h Helper) (bunHelper, bool) {
+	if group, ok := h.(groupHelper); ok {
+		h = group.Helper
+	}
+
+	base, ok := h.(bunHelper)
+
+	return base, ok
+}
+
+// DO NOT USE: this is only for generated code!
+//
+// Column returns column of the field.
+//
+// If relations are joined to the query the column
+// is qualified with the table alias, so it would
+// not be ambiguous with columns of the joined tables.
+func Column(h Helper, fieldName string) schema.QueryAppender {
+	name := h.ColumnName(fieldName)
+
+	base, ok := asBunHelper(h)
+	if !ok || base.alias != "" || base.joins == nil {
+		return bun.Ident(name)
+	}
+
+	return column{helper: base, name: name}
+}
+
+// column is qualified lazily, as relations
+// could be joined after the column was added.
+type column struct {
+	helper bunHelper
+	name   string
+}
+
+func (c column) AppendQuery(fmter schema.Formatter, b []byte) ([]byte, error) {
+	name := c.name
+	if len(c.helper.joins) > 0 {
+		name = c.helper.table.Alias + "." + name
+	}
+
+	return bun.Ident(name).AppendQuery(fmter, b)
+}
@@ -1,6 +1,59 @@
 package goquery
 
+import (
+	"reflect"
+	"slices"
+)
+
 // IsNull will be converted to `? IS NULL` filter.
-func IsNull(val any) bool { return true }
+//
+// When called in Go it reports whether val is nil.
+func IsNull(val any) bool {
+	return !indirect(reflect.ValueOf(val)).IsValid()
+}
+
+// In will be converted to `? IN (?)` filter.
+//
+// When called in Go it reports whether slice contains val.
+func In[T any](val T, slice []T) bool {
+	return slices.ContainsFunc(slice, func(item T) bool {
+		return reflect.DeepEqual(item, val)
+	})
+}
+
+// Any will be converted to `EXISTS (...)` subquery
+// on the related entities which satisfy the filter.
+func Any[T any](relation []T, filter func(val T) bool) bool {
+	for _, val := range relation {
+		if filter(val) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// All will be converted to `NOT EXISTS (...)` subquery
+// on the related entities which do not satisfy the filter.
+func All[T any](relation []T, filter func(val T) bool) bool {
+	for _, val := range relation {
+		if !filter(val) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Count will be converted to `(SELECT count(*) ...)` subquery
+// on the related entities which satisfy the filter.
+func Count[T any](relation []T, filter func(val T) bool) int {
+	var count int
+	for _, val := range relation {
+		if filter(val) {
+			count++
+		}
+	}
 
-func In[T any](val T, slice []T) bool { return true }
+	return count
+}
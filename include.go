@@ -0,0 +1,94 @@
+package goquery
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/uptrace/bun"
+)
+
+// IncludeWhere loads has-many or many-to-many relation
+// together with the rows, like Include does, but only
+// related entities that satisfy the filter are loaded:
+//
+//	goquery.IncludeWhere(q, func(u *User) []*Order { return u.Orders }, func(o *Order) bool {
+//		return o.Total > minTotal
+//	}, minTotal)
+//
+// The filter has the same limitations as in Where.
+func IncludeWhere[T, R any](q Queryable[T], relation func(val T) []R, filter func(val R) bool, args ...any) Queryable[T] {
+	// Relations of the items in memory are used as they are.
+	if _, ok := q.(*memoryQueryable[T]); ok {
+		return q
+	}
+
+	return includeQueryable(q, "IncludeWhere").include(getCaller(), "IncludeWhere", filter, args)
+}
+
+// ThenInclude loads the relation returned from nested
+// selector for each entity of the has-many or
+// many-to-many relation, and the relation itself:
+//
+//	goquery.ThenInclude(q, func(u *User) []*Order { return u.Orders }, func(o *Order) any {
+//		return o.Items
+//	})
+func ThenInclude[T, R any](q Queryable[T], relation func(val T) []R, nested func(val R) any) Queryable[T] {
+	if _, ok := q.(*memoryQueryable[T]); ok {
+		return q
+	}
+
+	return includeQueryable(q, "ThenInclude").include(getCaller(), "ThenInclude", nil, nil)
+}
+
+func includeQueryable[T any](q Queryable[T], method string) *queryable[T] {
+	source, ok := q.(*queryable[T])
+	if !ok {
+		panic(method + " is not supported for " + reflect.TypeOf(q).String())
+	}
+
+	return source
+}
+
+// DO NOT USE: this is only for generated code!
+//
+// IncludeRelation adds relation with the provided name
+// to the query. If the filter is set, it is applied
+// to the query of the related entities, with
+// a helper of the related entity.
+func IncludeRelation(h Helper, query bun.QueryBuilder, name string, filter QueryFunc, args ...any) {
+	selectQuery, ok := query.Unwrap().(*bun.SelectQuery)
+	if !ok {
+		panic("relations can be included only to select queries: " + name)
+	}
+
+	if filter == nil {
+		selectQuery.Relation(name)
+
+		return
+	}
+
+	base, ok := asBunHelper(h)
+	if !ok {
+		panic("relations are supported only with helper created by NewBunHelper")
+	}
+
+	table := base.table
+	for _, fieldName := range strings.Split(name, ".") {
+		rel, ok := table.Relations[fieldName]
+		if !ok {
+			panic("unknown relation: " + name)
+		}
+
+		table = rel.JoinTable
+	}
+
+	relHelper := newBunHelper(table)
+	relHelper.alias = table.Alias
+	relHelper.joins = make(map[string]bool)
+
+	selectQuery.Relation(name, func(query *bun.SelectQuery) *bun.SelectQuery {
+		filter(relHelper, query.QueryBuilder(), args...)
+
+		return query
+	})
+}
@@ -1,6 +1,8 @@
 package goquery
 
 import (
+	"context"
+
 	"github.com/uptrace/bun"
 )
 
@@ -12,12 +14,120 @@ type Queryable[T any] interface {
 	// Basic comparison against constants are supported though.
 	// See documentation for more info.
 	Where(filter func(val T) bool, args ...any) Queryable[T]
+	// OrderBy sorts the result in ascending order
+	// by the key returned from keySelector.
+	//
+	// It must be the first ordering call for the query,
+	// use ThenBy to add more ordering keys.
+	OrderBy(keySelector func(val T) any, args ...any) Queryable[T]
+	// OrderByDescending is the same as OrderBy,
+	// but sorts the result in descending order.
+	OrderByDescending(keySelector func(val T) any, args ...any) Queryable[T]
+	// ThenBy adds ascending ordering key to already
+	// ordered query.
+	ThenBy(keySelector func(val T) any, args ...any) Queryable[T]
+	// ThenByDescending is the same as ThenBy,
+	// but sorts the result in descending order.
+	ThenByDescending(keySelector func(val T) any, args ...any) Queryable[T]
+	// Skip skips first n rows of the result.
+	Skip(n int) Queryable[T]
+	// Take limits the result to at most n rows.
+	Take(n int) Queryable[T]
+	// After filters out rows that are not placed after
+	// the cursor with respect to the current ordering.
+	//
+	// It allows keyset pagination, where the last row
+	// of the previous page is used as a cursor:
+	//	q.OrderBy(...).ThenBy(...).After(lastRow).Take(20)
+	//
+	// It must be called after ordering is defined.
+	After(cursor T) Queryable[T]
+	// Include loads the relation returned from
+	// the selector together with the rows:
+	//	q.Include(func(u *User) any { return u.Orders })
+	//
+	// Relations of relations can be included by selecting
+	// them through belongs-to and has-one relations,
+	// or with ThenInclude.
+	Include(relation func(val T) any) Queryable[T]
+	// ToSlice executes the query and returns all resulting rows.
+	ToSlice(ctx context.Context) ([]T, error)
+	// First returns the first row of the result.
+	// ErrNoRows is returned if there are no rows.
+	First(ctx context.Context) (T, error)
+	// FirstOrDefault is the same as First, but returns
+	// zero value instead of ErrNoRows.
+	FirstOrDefault(ctx context.Context) (T, error)
+	// Single returns the only row of the result.
+	// ErrNoRows is returned if there are no rows
+	// and ErrMultipleRows if there is more than one.
+	Single(ctx context.Context) (T, error)
+	// Count returns number of rows in the result.
+	//
+	// Same as other terminal operators, Count
+	// applies paging set with Skip and Take.
+	Count(ctx context.Context) (int, error)
+	// Any reports whether the result has any rows.
+	Any(ctx context.Context) (bool, error)
+	// All reports whether all rows of the
+	// result satisfy the filter condition.
+	//
+	// The filter has the same limitations as in Where.
+	// Rows for which the filter is NULL in SQL do not
+	// satisfy it, same as they are not selected by Where.
+	All(ctx context.Context, filter func(val T) bool, args ...any) (bool, error)
+	// Update updates rows that satisfy filters
+	// of the query and returns number of updated rows.
+	//
+	// The lambda must only assign values to the fields
+	// of its parameter, each assignment becomes a column
+	// that is set by the update query:
+	//	q.Where(...).Update(ctx, func(u *User) {
+	//		u.Visits = u.Visits + 1
+	//		u.UpdatedAt = time.Now()
+	//	})
+	//
+	// Ordering, paging and included relations
+	// of the query are not used by the update.
+	Update(ctx context.Context, set func(val T), args ...any) (int64, error)
+	// Delete deletes rows that satisfy filters of the
+	// query and returns number of deleted rows.
+	//
+	// Rows of models with soft delete column(`bun:",soft_delete"`)
+	// are only marked as deleted, use ForceDelete to remove them.
+	Delete(ctx context.Context) (int64, error)
+	// ForceDelete is the same as Delete, but
+	// removes rows of soft delete models.
+	ForceDelete(ctx context.Context) (int64, error)
 	// Query returns a *bun.SelectQuery that is
 	// used by this Queryable.
 	Query() *bun.SelectQuery
 }
 
-type QueryFunc func(h Helper, query *bun.SelectQuery, args ...any)
+// QueryFunc adds filter to the query.
+//
+// The query could be any of select, update or delete queries.
+type QueryFunc func(h Helper, query bun.QueryBuilder, args ...any)
+
+// ExprFunc returns SQL expression with its arguments.
+//
+// The query is only modified if the expression
+// requires relations to be joined to it.
+type ExprFunc func(h Helper, query *bun.SelectQuery, args ...any) (string, []any)
+
+// ProjectionFunc adds columns of the projection to the query.
+//
+// Helper h is used for columns of the queried entity
+// and resultHelper for columns of the projection result.
+type ProjectionFunc func(h Helper, resultHelper Helper, query *bun.SelectQuery, args ...any)
+
+// UpdateFunc sets columns that are updated by the query.
+type UpdateFunc func(h Helper, query *bun.UpdateQuery, args ...any)
+
+// CaptureFunc returns values of the local variables captured
+// by the lambda fn, that are passed to the generated
+// function after the arguments of the call.
+type CaptureFunc func(fn any) []any
 
 type Helper interface {
 	// ColumnName must return SQL column name for the given field.
@@ -28,8 +138,25 @@ type Helper interface {
 type Caller struct {
 	File string
 	Line int
+	// Index tells apart chained calls of the same
+	// kind on the line, in order of their evaluation.
+	Index int
 }
 
 type Calls struct {
-	Where map[Caller]QueryFunc
+	Where   map[Caller]QueryFunc
+	OrderBy map[Caller]ExprFunc
+	Select  map[Caller]ProjectionFunc
+	GroupBy map[Caller]ExprFunc
+	Include map[Caller]QueryFunc
+	Update  map[Caller]UpdateFunc
+
+	// Captures of the lambdas by the calls field.
+	Captures map[string]map[Caller]CaptureFunc
+
+	// lineCalls holds number of generated calls of the same
+	// kind on the line, if there is more than one of them.
+	// Calls of other entities on the line are not counted,
+	// as they are made on queryables of other entities.
+	lineCalls map[callSite]int
 }
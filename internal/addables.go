@@ -17,12 +17,14 @@ type addableGenerator func(p *whereBodyParser, s ast.Expr, args map[string]int)
 type typedGenerator[T ast.Expr] func(p *whereBodyParser, s T, args map[string]int) Addable
 
 var typeMethods = map[string]map[string]typedGenerator[*ast.CallExpr]{}
+var typeFields = map[string]map[string]typedGenerator[*ast.SelectorExpr]{}
 var packageFuncs = map[string]map[string]typedGenerator[*ast.CallExpr]{}
 
 func init() {
 	// Define these generators before everything else,
 	// so they will not be shadowed by others if they do not return nil.
 	addTypeMethodGenerators()
+	addTypeFieldGenerators()
 	addPackageFuncGenerators()
 	addBinaryGenerators()
 	addPackageIdentGenerators()
@@ -32,64 +34,88 @@ func init() {
 	// Basic generators, they are not specific
 	// to some type, package or variable/const.
 	addGenerator(func(p *whereBodyParser, s *ast.BasicLit, args map[string]int) Addable {
-		return NewSimple(param, getArg(s))
+		return NewSimple(param, p.literalArg(s))
 	})
 	addGenerator(func(p *whereBodyParser, s *ast.BinaryExpr, args map[string]int) Addable {
 		return p.fromBinaryExpr(s, args)
 	})
 	addGenerator(func(p *whereBodyParser, s *ast.SelectorExpr, args map[string]int) Addable {
-		// Supports more cases for arguments
-		gotExprName := p.c.exprName(s)
-		if !strings.HasPrefix(gotExprName, p.paramName+".") {
-			argPos, ok := args[gotExprName]
-			if !ok {
-				p.c.panicWithPosf(s, "argument is not provided: "+gotExprName)
-			}
-
-			return NewSimple(param, fromArgs(argPos))
+		if column, ok := p.column(s); ok {
+			return column
 		}
 
-		return NewColumn(s.Sel.Name)
+		return NewSimple(param, p.argValue(s, args))
 	})
 	addGenerator(func(p *whereBodyParser, s *ast.ParenExpr, args map[string]int) Addable {
-		return Parens{p.exprToAddable(s.X, args)}
+		return Parens{Addable: p.exprToAddable(s.X, args)}
 	})
 	addGenerator(func(p *whereBodyParser, s *ast.Ident, args map[string]int) Addable {
 		switch s.Name {
 		case "true", "false":
-			// True/false values do not have Obj set,
-			// and if it is set - the value is re-defined.
-			if s.Obj != nil {
+			// The value may be re-defined.
+			if p.c.TypeInfo.Uses[s] != types.Universe.Lookup(s.Name) {
 				break
 			}
 
 			return NewSimple(param, raw(s.Name))
 		}
 
-		if s.Obj != nil && s.Obj.Kind == ast.Con {
-			defObj := p.c.TypeInfo.ObjectOf(s.Obj.Decl.(*ast.ValueSpec).Values[0].(*ast.SelectorExpr).Sel)
-			_ = defObj
+		return NewSimple(param, p.argValue(s, args))
+	})
+	addGenerator(func(p *whereBodyParser, s *ast.CallExpr, args map[string]int) Addable {
+		// Conversions between basic types, like `float64(val)`.
+		funType, ok := p.c.TypeInfo.Types[s.Fun]
+		if !ok || !funType.IsType() {
+			return nil
+		}
 
-			if valSpec, ok := s.Obj.Decl.(*ast.ValueSpec); ok {
-				if basicLit, ok := valSpec.Values[0].(*ast.BasicLit); ok {
-					return NewSimple(param, getArg(basicLit))
-				}
-			}
+		toType, ok := funType.Type.Underlying().(*types.Basic)
+		if !ok {
+			return nil
 		}
 
-		argPos, ok := args[s.Name]
+		fromType, ok := p.c.TypeInfo.TypeOf(s.Args[0]).Underlying().(*types.Basic)
 		if !ok {
-			p.c.panicWithPosf(s, "argument is not provided: "+s.Name)
+			p.c.panicWithPosf(s, "conversion to %s is not supported", toType)
+		}
+
+		addable := p.getAddable(s.Args[0], args)
+
+		switch {
+		case isFloat(toType) && isInteger(fromType):
+			// Integer values must be converted explicitly,
+			// otherwise they could not be scanned into floats.
+			return Wrapper{
+				Addable: addable,
+				StringF: func(a Addable) string { return castToFloat(p.dialect, a.String()) },
+			}
+		case isInteger(toType) && isFloat(fromType):
+			// Fractional part is dropped, as in Go.
+			return Wrapper{
+				Addable: addable,
+				StringF: func(a Addable) string { return truncate(p.dialect, a.String()) },
+			}
+		case fromType.Info()&types.IsUntyped != 0, widens(fromType, toType):
+			// Constants are checked by the compiler to be
+			// representable by the type, and widening
+			// conversions do not change the value.
+			return addable
 		}
 
-		return NewSimple(param, fromArgs(argPos))
+		p.c.panicWithPosf(s, "conversion from %s to %s is not supported, as it may change the value", fromType, toType)
+		return nil
 	})
 	addGenerator(func(p *whereBodyParser, s *ast.UnaryExpr, args map[string]int) Addable {
 		switch s.Op {
 		case token.NOT:
 			return Not{p.getAddable(s.X, args)}
 		case token.SUB:
-			return Neg{p.getAddable(s.X, args)}
+			value := p.getAddable(s.X, args)
+			if interval, ok := value.(interval); ok {
+				return interval.times(NewSimple("-1"))
+			}
+
+			return Neg{value}
 		case token.ADD:
 			// Do not care about plus sign before
 			// value as it does not change the result.
@@ -100,8 +126,20 @@ func init() {
 		}
 	})
 
+	// Index expressions are taken from the closure or passed as
+	// arguments, composite literals can only be passed as arguments.
+	addGenerator(func(p *whereBodyParser, s *ast.IndexExpr, args map[string]int) Addable {
+		return NewSimple(param, p.argValue(s, args))
+	})
+	addGenerator(func(p *whereBodyParser, s *ast.CompositeLit, args map[string]int) Addable {
+		return NewSimple(param, p.argValue(s, args))
+	})
+
 	addPackageFuncGenerator("goquery", "In", GoQueryPackage{}.in)
 	addPackageFuncGenerator("goquery", "IsNull", GoQueryPackage{}.isNull)
+	addPackageFuncGenerator("goquery", "Any", GoQueryPackage{}.relation("EXISTS (SELECT 1 FROM ? WHERE ? AND (%s))", false))
+	addPackageFuncGenerator("goquery", "All", GoQueryPackage{}.relation("NOT EXISTS (SELECT 1 FROM ? WHERE ? AND (%s))", true))
+	addPackageFuncGenerator("goquery", "Count", GoQueryPackage{}.relation("(SELECT count(*) FROM ? WHERE ? AND (%s))", false))
 
 	addPackageFuncGenerator("time", "Now", TimePackage{}.now)
 
@@ -114,7 +152,15 @@ func init() {
 	addTypeFuncGenerator("time.Time", "After", TimeType{}.binary(tokenToOperation(token.GTR)))
 	addTypeFuncGenerator("time.Time", "Before", TimeType{}.binary(tokenToOperation(token.LSS)))
 	addTypeFuncGenerator("time.Time", "Equal", TimeType{}.binary(tokenToOperation(token.EQL)))
-	addTypeFuncGenerator("time.Time", "Add", TimeType{}.binary(tokenToOperation(token.ADD)))
+	addTypeFuncGenerator("time.Time", "Add", TimeType{}.add)
+
+	addTypeFuncGenerator("goquery.Group", "Count", GroupType{}.count)
+	addTypeFuncGenerator("goquery.Group", "Sum", GroupType{}.aggregate("sum"))
+	addTypeFuncGenerator("goquery.Group", "Min", GroupType{}.aggregate("min"))
+	addTypeFuncGenerator("goquery.Group", "Max", GroupType{}.aggregate("max"))
+	addTypeFuncGenerator("goquery.Group", "Avg", GroupType{}.aggregate("avg"))
+
+	addTypeFieldGenerator("goquery.Group", "Key", GroupType{}.key)
 
 	addBinaryTypeGenerator("string", "string", stringBinaryTypeGenerator)
 
@@ -124,6 +170,7 @@ func init() {
 	addPackageIdentGenerator("time", "Minute", timeIdentsGenerator)
 	addPackageIdentGenerator("time", "Hour", timeIdentsGenerator)
 
+	addInlineGenerators()
 }
 
 func wrapper[T ast.Expr](f func(p *whereBodyParser, s T, args map[string]int) Addable) addableGenerator {
@@ -133,6 +180,26 @@ func wrapper[T ast.Expr](f func(p *whereBodyParser, s T, args map[string]int) Ad
 }
 
 func (p *whereBodyParser) getAddable(expr ast.Expr, args map[string]int) Addable {
+	// Expressions passed as arguments, like `ids[0]`
+	// or `req.GetEmail()`, are used as they are.
+	if len(args) != 0 {
+		if key, ok := p.c.exprKey(expr); ok {
+			if argPos, ok := args[key]; ok {
+				return NewSimple(param, fromArgs(argPos))
+			}
+		}
+	}
+
+	if ident, ok := expr.(*ast.Ident); ok {
+		if value, ok := p.value(ident); ok {
+			return value
+		}
+
+		if local, ok := p.local(ident); ok {
+			return p.localAddable(local, args)
+		}
+	}
+
 	strTp := typeKey(expr)
 
 	generators, ok := addableGenerators[strTp]
@@ -146,11 +213,52 @@ func (p *whereBodyParser) getAddable(expr ast.Expr, args map[string]int) Addable
 		}
 	}
 
+	if call, ok := expr.(*ast.CallExpr); ok {
+		p.c.panicWithPosf(expr, "function %s is not supported", p.c.exprString(call.Fun))
+	}
+
 	p.c.panicWithPosf(expr, "didn't find any suitable generator for type %q", strTp)
 
 	return nil
 }
 
+// argValue returns expression of the value of the variable
+// captured by the lambda. Package-level variables are used
+// directly, and local variables are either passed as arguments
+// or taken from the closure of the lambda.
+func (p *whereBodyParser) argValue(expr ast.Expr, args map[string]int) raw {
+	if p.c.packageVar(expr) {
+		return raw(p.c.exprName(expr))
+	}
+
+	argPos, ok := args[p.c.argName(expr)]
+	if !ok {
+		argPos, ok = p.closure.arg(p.c, expr)
+	}
+
+	if !ok {
+		p.c.panicWithPosf(expr, "argument is not provided: "+p.c.exprString(expr))
+	}
+
+	return fromArgs(argPos)
+}
+
+// localAddable returns Addable of the expression bound
+// to local variable, which is used instead of the variable.
+func (p *whereBodyParser) localAddable(local ast.Expr, args map[string]int) Addable {
+	addable := p.getAddable(local, args)
+
+	// Operations must be applied to the whole expression,
+	// but intervals are kept as is to be added to time.
+	if _, ok := local.(*ast.BinaryExpr); ok {
+		if _, ok := addable.(interval); !ok {
+			return Parens{Addable: addable}
+		}
+	}
+
+	return addable
+}
+
 func addGenerator[T ast.Expr](f typedGenerator[T]) {
 	var t T
 	strTp := typeKey(t)
@@ -176,7 +284,7 @@ func addPackageFuncGenerators() {
 		}
 
 		ident, ok := selector.X.(*ast.Ident)
-		if !ok || ident.Obj != nil {
+		if !ok || !p.c.isPackageName(ident) {
 			return nil
 		}
 
@@ -251,6 +359,10 @@ func (p *whereBodyParser) exprType(t ast.Expr) (string, bool) {
 
 	tp := p.c.TypeInfo.TypeOf(t)
 
+	if basicTp, ok := tp.(*types.Basic); ok {
+		return strings.TrimPrefix(basicTp.Name(), "untyped "), true
+	}
+
 	namedTp, ok := tp.(*types.Named)
 	if !ok {
 		return "", false
@@ -262,3 +374,71 @@ func (p *whereBodyParser) exprType(t ast.Expr) (string, bool) {
 
 	return namedTp.Obj().Pkg().Name() + "." + namedTp.Obj().Name(), true
 }
+
+func addTypeFieldGenerator(typeName string, fieldName string, generator typedGenerator[*ast.SelectorExpr]) {
+	mp := typeFields[typeName]
+	if mp == nil {
+		mp = map[string]typedGenerator[*ast.SelectorExpr]{}
+		typeFields[typeName] = mp
+	}
+
+	mp[fieldName] = generator
+}
+
+func addTypeFieldGenerators() {
+	addGenerator(func(p *whereBodyParser, s *ast.SelectorExpr, args map[string]int) Addable {
+		strExprType, ok := p.exprType(s.X)
+		if !ok {
+			return nil
+		}
+
+		typeFieldMap, ok := typeFields[strExprType]
+		if !ok {
+			return nil
+		}
+
+		generator, ok := typeFieldMap[s.Sel.Name]
+		if !ok {
+			return nil
+		}
+
+		return generator(p, s, args)
+	})
+}
+
+// sizes of the basic types. Size of int does not matter
+// much, as integer columns of the databases are 64-bit.
+var sizes = types.SizesFor("gc", "amd64")
+
+func isInteger(typ *types.Basic) bool {
+	return typ.Info()&types.IsInteger != 0
+}
+
+func isFloat(typ *types.Basic) bool {
+	return typ.Info()&types.IsFloat != 0
+}
+
+// widens reports whether values of from type
+// are converted to the type without changes.
+func widens(from, to *types.Basic) bool {
+	fromSize, toSize := sizes.Sizeof(from), sizes.Sizeof(to)
+
+	switch {
+	case from.Kind() == to.Kind():
+		return true
+	case isInteger(from) && isInteger(to):
+		fromUnsigned := from.Info()&types.IsUnsigned != 0
+		toUnsigned := to.Info()&types.IsUnsigned != 0
+
+		if fromUnsigned == toUnsigned {
+			return toSize >= fromSize
+		}
+
+		// Unsigned values fit into larger signed types.
+		return fromUnsigned && toSize > fromSize
+	case isFloat(from) && isFloat(to):
+		return toSize >= fromSize
+	default:
+		return false
+	}
+}
@@ -0,0 +1,1026 @@
+// Code generated by goquery; DO NOT EDIT.
+
+package internal_test
+
+import (
+	math "math"
+	rt "runtime"
+
+	"github.com/ffenix113/goquery"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	goquery.RegisterPackagePath("github.com/ffenix113/goquery/internal")
+	goquery.AddToGlobalEntity[*Extensive](
+		goquery.Calls{
+			Where: map[goquery.Caller]goquery.QueryFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 52}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ? AND ? > ? AND ? < ? AND ? >= ? AND ? <= ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							"eql",
+							goquery.Column(helper, "StringCol"),
+							"gt",
+							goquery.Column(helper, "StringCol"),
+							"lt",
+							goquery.Column(helper, "StringCol"),
+							"gte",
+							goquery.Column(helper, "IntCol"),
+							5}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 62}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ? AND (? > ? OR ? = ?)",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							"eql",
+							goquery.Column(helper, "StringCol"),
+							"gt",
+							goquery.Column(helper, "StringCol"),
+							"another"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 72}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ? OR ? = ? AND ? >= ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							args[0],
+							goquery.Column(helper, "StringCol"),
+							goquery.Column(helper, "StringCol2"),
+							goquery.Column(helper, "IntCol"),
+							args[1]}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 82}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? IN (?)",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							bun.In(args[0])}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 92}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ? AND ? IN (?)",
+						[]any{
+							goquery.Column(helper, "StringCol2"),
+							args[0],
+							goquery.Column(helper, "StringCol"),
+							bun.In(args[1])}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 101}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? > ? AND ? = ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							packageVar,
+							goquery.Column(helper, "StringCol"),
+							packageConfig.Name}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 110}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? < ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							rt.MemProfileRate}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 119}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? > ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							packageVar}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 124}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? < ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							args[0]}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 135}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ? OR ? = ? OR ? IN (?) OR ? < ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							args[0],
+							goquery.Column(helper, "StringCol"),
+							args[1],
+							goquery.Column(helper, "IntCol"),
+							bun.In(args[2]),
+							goquery.Column(helper, "IntCol"),
+							args[3]}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 146}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ? OR ? = ? AND ? >= ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							args[0],
+							goquery.Column(helper, "StringCol"),
+							goquery.Column(helper, "StringCol2"),
+							goquery.Column(helper, "IntCol"),
+							args[1]}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 159}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ? AND ? != ? OR ? IN (?)",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							args[0],
+							goquery.Column(helper, "IntCol"),
+							args[1],
+							goquery.Column(helper, "IntCol"),
+							bun.In(args[2])}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 169}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ? AND ? < ? AND ? != ?",
+						[]any{
+							goquery.Column(helper, "StringCol2"),
+							args[1],
+							goquery.Column(helper, "IntCol"),
+							args[0],
+							goquery.Column(helper, "StringCol"),
+							args[1]}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 181}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? > ? AND ? != ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							args[0],
+							goquery.Column(helper, "IntCol"),
+							args[1]}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 193}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ? AND ? > ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							args[0],
+							goquery.Column(helper, "IntCol"),
+							args[1]}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 202}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? IS NULL",
+						[]any{
+							goquery.Column(helper, "StringCol")}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 211}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where(goquery.DialectQuery(query, "? = ? || ?", map[string]string{
+						"mssql": "? = CONCAT(?, ?)",
+						"mysql": "? = CONCAT(?, ?)",
+					}),
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							"1",
+							"2"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 220}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where(goquery.DialectQuery(query, "? + -? * INTERVAL '1 second' = NOW()", map[string]string{
+						"mssql":  "DATEADD(second, -?, ?) = SYSDATETIME()",
+						"mysql":  "DATE_ADD(?, INTERVAL -? SECOND) = NOW()",
+						"sqlite": "(rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', ?, (-?) || ' seconds'), '0'), '.') || '+00:00') = (rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', 'now'), '0'), '.') || '+00:00')",
+					}),
+						goquery.DialectArgs(query, []any{
+							goquery.Column(helper, "TimeCol"),
+							3}, map[string][]any{
+							"mssql": {
+								3,
+								goquery.Column(helper, "TimeCol")},
+						})...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 229}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? < ? * 1000000000",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							2}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 239}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							0}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 248}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							1}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 257}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							3}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 266}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							math.MaxInt8}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 275}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where(goquery.DialectQuery(query, "upper(?) = ? AND (? + ?) * ? > ? AND ? > NOW() + -? * INTERVAL '1 hour'", map[string]string{
+						"mssql":  "upper(?) = ? AND (? + ?) * ? > ? AND ? > DATEADD(hour, -?, SYSDATETIME())",
+						"mysql":  "upper(?) = ? AND (? + ?) * ? > ? AND ? > DATE_ADD(NOW(), INTERVAL -? HOUR)",
+						"sqlite": "upper(?) = ? AND (? + ?) * ? > ? AND ? > (rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', (rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', 'now'), '0'), '.') || '+00:00'), (-?) || ' hours'), '0'), '.') || '+00:00')",
+					}),
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							"A",
+							goquery.Column(helper, "IntCol"),
+							1,
+							2,
+							4,
+							goquery.Column(helper, "TimeCol"),
+							24}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 287}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("not (? < ?) AND ((? = ?) OR ((? = ?) AND (? > ?) OR not (? = ?) AND (? != ?)))",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							0,
+							goquery.Column(helper, "StringCol"),
+							"a",
+							goquery.Column(helper, "StringCol"),
+							"b",
+							goquery.Column(helper, "IntCol"),
+							5,
+							goquery.Column(helper, "StringCol"),
+							"b",
+							goquery.Column(helper, "StringCol2"),
+							""}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 306}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("((? = ?) OR (? = ?)) AND (? > ?) OR not ((? = ?) OR (? = ?)) AND (not (? = ?) AND (? != ?))",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							"gold",
+							goquery.Column(helper, "StringCol"),
+							"silver",
+							goquery.Column(helper, "IntCol"),
+							1000,
+							goquery.Column(helper, "StringCol"),
+							"gold",
+							goquery.Column(helper, "StringCol"),
+							"silver",
+							goquery.Column(helper, "StringCol"),
+							"basic",
+							goquery.Column(helper, "StringCol2"),
+							""}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 383}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where(goquery.DialectQuery(query, "? || ? LIKE '%' || ? || '%'", map[string]string{
+						"mssql": "CONCAT(?, ?) LIKE CONCAT('%', ?, '%')",
+						"mysql": "CONCAT(?, ?) LIKE CONCAT('%', ?, '%')",
+					}),
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							"x",
+							goquery.Column(helper, "StringCol2")}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 384}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where(goquery.DialectQuery(query, "? + ? * INTERVAL '1 hour' > NOW() AND ? + INTERVAL '1 millisecond' < NOW()", map[string]string{
+						"mssql":  "DATEADD(hour, ?, ?) > SYSDATETIME() AND DATEADD(millisecond, 1, ?) < SYSDATETIME()",
+						"mysql":  "DATE_ADD(?, INTERVAL ? HOUR) > NOW() AND DATE_ADD(?, INTERVAL (1) * 1000 MICROSECOND) < NOW()",
+						"sqlite": "(rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', ?, (?) || ' hours'), '0'), '.') || '+00:00') > (rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', 'now'), '0'), '.') || '+00:00') AND (rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', ?, (1 / 1000.0) || ' seconds'), '0'), '.') || '+00:00') < (rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', 'now'), '0'), '.') || '+00:00')",
+					}),
+						goquery.DialectArgs(query, []any{
+							goquery.Column(helper, "TimeCol"),
+							2,
+							goquery.Column(helper, "TimeCol")}, map[string][]any{
+							"mssql": {
+								2,
+								goquery.Column(helper, "TimeCol"),
+								goquery.Column(helper, "TimeCol")},
+						})...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 387}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where(goquery.DialectQuery(query, "CAST(? AS DOUBLE PRECISION) > ? AND CAST(TRUNC(CAST(? AS DOUBLE PRECISION) / ?) AS BIGINT) = ?", map[string]string{
+						"mssql":  "CAST(? AS FLOAT) > ? AND CAST(CAST(? AS FLOAT) / ? AS BIGINT) = ?",
+						"mysql":  "CAST(? AS DOUBLE) > ? AND CAST(TRUNCATE(CAST(? AS DOUBLE) / ?, 0) AS SIGNED) = ?",
+						"sqlite": "CAST(? AS DOUBLE PRECISION) > ? AND CAST(CAST(? AS DOUBLE PRECISION) / ? AS INTEGER) = ?",
+					}),
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							1.5,
+							goquery.Column(helper, "IntCol"),
+							2,
+							1}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 388}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("not (? = ?) OR (? > ?)",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							"a",
+							goquery.Column(helper, "IntCol"),
+							1}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 477}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? > ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							1}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 533}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? > ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							args[0]}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 533, Index: 1}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? != ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							"a"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 548}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? > ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							1}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 548, Index: 1}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? < ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							5}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 599}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? > ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							0}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 657}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? > ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							1}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 678}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? > ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							10}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 681}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? > ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							10}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 687}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							"b"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 691}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							"d"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 694}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? < ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							3}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 704}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? >= ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							2}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 708}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							"c"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 712}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							"d"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 728}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where(goquery.DialectQuery(query, "not (? > ?) OR (? > ?) IS NULL", map[string]string{
+						"mssql": "CASE WHEN ? > ? THEN 0 ELSE 1 END = 1",
+					}),
+						goquery.DialectArgs(query, []any{
+							goquery.Column(helper, "IntCol"),
+							0,
+							goquery.Column(helper, "IntCol"),
+							0}, map[string][]any{
+							"mssql": {
+								goquery.Column(helper, "IntCol"),
+								0},
+						})...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 733}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where(goquery.DialectQuery(query, "not (? >= ?) OR (? >= ?) IS NULL", map[string]string{
+						"mssql": "CASE WHEN ? >= ? THEN 0 ELSE 1 END = 1",
+					}),
+						goquery.DialectArgs(query, []any{
+							goquery.Column(helper, "IntCol"),
+							args[0],
+							goquery.Column(helper, "IntCol"),
+							args[0]}, map[string][]any{
+							"mssql": {
+								goquery.Column(helper, "IntCol"),
+								args[0]},
+						})...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 738}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? >= ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							2}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 739}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where(goquery.DialectQuery(query, "not (? >= ?) OR (? >= ?) IS NULL", map[string]string{
+						"mssql": "CASE WHEN ? >= ? THEN 0 ELSE 1 END = 1",
+					}),
+						goquery.DialectArgs(query, []any{
+							goquery.Column(helper, "IntCol"),
+							args[0],
+							goquery.Column(helper, "IntCol"),
+							args[0]}, map[string][]any{
+							"mssql": {
+								goquery.Column(helper, "IntCol"),
+								args[0]},
+						})...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 748}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? >= ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							2}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 749}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where(goquery.DialectQuery(query, "not (? != ?) OR (? != ?) IS NULL", map[string]string{
+						"mssql": "CASE WHEN ? != ? THEN 0 ELSE 1 END = 1",
+					}),
+						goquery.DialectArgs(query, []any{
+							goquery.Column(helper, "StringCol"),
+							"a",
+							goquery.Column(helper, "StringCol"),
+							"a"}, map[string][]any{
+							"mssql": {
+								goquery.Column(helper, "StringCol"),
+								"a"},
+						})...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 773}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? > ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							1}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 894}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? > ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							1}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 896}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Unwrap().(*bun.SelectQuery).Having("count(*) > ? AND ? != ?",
+						[]any{
+							args[0],
+							goquery.GroupKey(helper),
+							"B"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 934}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? != ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							"b"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 945}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? > ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							20}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 946}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(helper, "StringCol2"),
+							"C"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 956}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							"a"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 963}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ? + ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							args[0],
+							1}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 1002}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? >= ? AND ? != ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							2,
+							goquery.Column(helper, "StringCol"),
+							"c"}...)
+				},
+			},
+			OrderBy: map[goquery.Caller]goquery.ExprFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 427}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "StringCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 434}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "IntCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 435}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "StringCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 436}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "TimeCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 444}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "? * ?", []any{
+						goquery.Column(helper, "IntCol"),
+						args[0]}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 454}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "? * ?", []any{
+						goquery.Column(helper, "IntCol"),
+						args[0]}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 455}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "? + ?", []any{
+						goquery.Column(helper, "IntCol"),
+						args[0]}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 462}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "CASE WHEN ? > ? THEN ? WHEN ? > ? THEN ? ELSE ? END", []any{
+						goquery.Column(helper, "IntCol"),
+						10,
+						goquery.Column(helper, "StringCol"),
+						goquery.Column(helper, "IntCol"),
+						5,
+						goquery.Column(helper, "StringCol2"),
+						""}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 478}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "lower(?)", []any{
+						goquery.Column(helper, "StringCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 508}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "IntCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 511}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "IntCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 514}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "StringCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 540}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "IntCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 540, Index: 1}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "StringCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 567}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "IntCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 574}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "IntCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 581}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "StringCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 582}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "IntCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 590}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "StringCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 591}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "IntCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 600}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "upper(?)", []any{
+						goquery.Column(helper, "StringCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 658}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "IntCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 667}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "IntCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 698}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "IntCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 717}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "IntCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 839}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "StringCol2")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 969}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "StringCol")}
+				},
+			},
+			Select: map[goquery.Caller]goquery.ProjectionFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 775}: func(helper goquery.Helper, resultHelper goquery.Helper, query *bun.SelectQuery, args ...any) {
+					query.ColumnExpr("? AS ?, upper(?) AS ?, ? * ? AS ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							bun.Ident(resultHelper.ColumnName("Name")),
+							goquery.Column(helper, "StringCol2"),
+							bun.Ident(resultHelper.ColumnName("Upper")),
+							goquery.Column(helper, "IntCol"),
+							goquery.Column(helper, "IntCol"),
+							bun.Ident(resultHelper.ColumnName("Total"))}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 788}: func(helper goquery.Helper, resultHelper goquery.Helper, query *bun.SelectQuery, args ...any) {
+					query.ColumnExpr("? AS ?, ? * ? AS ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							bun.Ident(resultHelper.ColumnName("Name")),
+							goquery.Column(helper, "IntCol"),
+							args[0],
+							bun.Ident(resultHelper.ColumnName("Total"))}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 801}: func(helper goquery.Helper, resultHelper goquery.Helper, query *bun.SelectQuery, args ...any) {
+					query.ColumnExpr(goquery.DialectQuery(query, "? || ? AS ?", map[string]string{
+						"mssql": "CONCAT(?, ?) AS ?",
+						"mysql": "CONCAT(?, ?) AS ?",
+					}),
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							args[0],
+							bun.Ident(resultHelper.ColumnName("Name"))}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 809}: func(helper goquery.Helper, resultHelper goquery.Helper, query *bun.SelectQuery, args ...any) {
+					query.ColumnExpr("CASE WHEN ? > ? THEN ? ELSE ? END AS ?, CASE WHEN ? > ? THEN ? * ? ELSE ? END AS ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							1,
+							goquery.Column(helper, "StringCol"),
+							"small",
+							bun.Ident(resultHelper.ColumnName("Name")),
+							goquery.Column(helper, "IntCol"),
+							1,
+							goquery.Column(helper, "IntCol"),
+							10,
+							goquery.Column(helper, "IntCol"),
+							bun.Ident(resultHelper.ColumnName("Total"))}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 824}: func(helper goquery.Helper, resultHelper goquery.Helper, query *bun.SelectQuery, args ...any) {
+					query.ColumnExpr("? AS ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							bun.Ident(resultHelper.ColumnName("Name"))}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 839}: func(helper goquery.Helper, resultHelper goquery.Helper, query *bun.SelectQuery, args ...any) {
+					query.ColumnExpr("? AS ?, ? AS ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							bun.Ident(resultHelper.ColumnName("Name")),
+							goquery.Column(helper, "IntCol"),
+							bun.Ident(resultHelper.ColumnName("Total"))}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 875}: func(helper goquery.Helper, resultHelper goquery.Helper, query *bun.SelectQuery, args ...any) {
+					query.ColumnExpr(goquery.DialectQuery(query, "? AS ?, count(*) AS ?, sum(CAST(? AS DOUBLE PRECISION)) AS ?, avg(CAST(? AS DOUBLE PRECISION)) AS ?, max(CAST(? AS DOUBLE PRECISION)) AS ?", map[string]string{
+						"mssql": "? AS ?, count(*) AS ?, sum(CAST(? AS FLOAT)) AS ?, avg(CAST(? AS FLOAT)) AS ?, max(CAST(? AS FLOAT)) AS ?",
+						"mysql": "? AS ?, count(*) AS ?, sum(CAST(? AS DOUBLE)) AS ?, avg(CAST(? AS DOUBLE)) AS ?, max(CAST(? AS DOUBLE)) AS ?",
+					}),
+						[]any{
+							goquery.GroupKey(helper),
+							bun.Ident(resultHelper.ColumnName("Name")),
+							bun.Ident(resultHelper.ColumnName("Count")),
+							goquery.Column(helper, "IntCol"),
+							bun.Ident(resultHelper.ColumnName("Total")),
+							goquery.Column(helper, "IntCol"),
+							bun.Ident(resultHelper.ColumnName("Avg")),
+							goquery.Column(helper, "IntCol"),
+							bun.Ident(resultHelper.ColumnName("Max"))}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 900}: func(helper goquery.Helper, resultHelper goquery.Helper, query *bun.SelectQuery, args ...any) {
+					query.ColumnExpr("? AS ?, count(*) AS ?",
+						[]any{
+							goquery.GroupKey(helper),
+							bun.Ident(resultHelper.ColumnName("Name")),
+							bun.Ident(resultHelper.ColumnName("Count"))}...)
+				},
+			},
+			GroupBy: map[goquery.Caller]goquery.ExprFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 873}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "StringCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 894}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "upper(?)", []any{
+						goquery.Column(helper, "StringCol")}
+				},
+			},
+			Update: map[goquery.Caller]goquery.UpdateFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 935}: func(helper goquery.Helper, query *bun.UpdateQuery, args ...any) {
+					query.Set("? = ? * ?, ? = upper(?)",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							goquery.Column(helper, "IntCol"),
+							args[0],
+							goquery.Column(helper, "StringCol2"),
+							goquery.Column(helper, "StringCol")}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 947}: func(helper goquery.Helper, query *bun.UpdateQuery, args ...any) {
+					query.Set("? = ? + (? - ?)",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							goquery.Column(helper, "IntCol"),
+							2,
+							1}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 957}: func(helper goquery.Helper, query *bun.UpdateQuery, args ...any) {
+					query.Set("? = ? - 1",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							goquery.Column(helper, "IntCol")}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 964}: func(helper goquery.Helper, query *bun.UpdateQuery, args ...any) {
+					query.Set(goquery.DialectQuery(query, "? = ? || ?", map[string]string{
+						"mssql": "? = CONCAT(?, ?)",
+						"mysql": "? = CONCAT(?, ?)",
+					}),
+						[]any{
+							goquery.Column(helper, "StringCol2"),
+							goquery.Column(helper, "StringCol2"),
+							args[0]}...)
+				},
+			},
+			Captures: map[string]map[goquery.Caller]goquery.CaptureFunc{
+				"OrderBy": {
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 454}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 int
+						})(goquery.Closure(fn))
+
+						return []any{closure.V0}
+					},
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 455}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 int
+						})(goquery.Closure(fn))
+
+						return []any{closure.V0}
+					},
+				},
+				"Select": {
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 801}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 string
+						})(goquery.Closure(fn))
+
+						return []any{closure.V0}
+					},
+				},
+				"Update": {
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 964}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 string
+						})(goquery.Closure(fn))
+
+						return []any{closure.V0}
+					},
+				},
+				"Where": {
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 146}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 string
+							V1 int
+						})(goquery.Closure(fn))
+
+						return []any{closure.V0, closure.V1}
+					},
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 159}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 struct {
+								Name string
+								IDs  []int
+							}
+						})(goquery.Closure(fn))
+
+						return []any{closure.V0.Name, closure.V0.IDs[1], closure.V0.IDs}
+					},
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 169}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 string
+							V1 int
+						})(goquery.Closure(fn))
+
+						return []any{closure.V0}
+					},
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 181}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 *int
+							V1 *int
+						})(goquery.Closure(fn))
+
+						return []any{(*closure.V0), (*closure.V1)}
+					},
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 193}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 *string
+							V1 int
+						})(goquery.Closure(fn))
+
+						return []any{(*closure.V0), closure.V1}
+					},
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 963}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 int
+						})(goquery.Closure(fn))
+
+						return []any{closure.V0}
+					},
+				},
+			},
+		},
+	)
+
+	goquery.AddToGlobalEntity[*Note](
+		goquery.Calls{
+			Where: map[goquery.Caller]goquery.QueryFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 548}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? != ?",
+						[]any{
+							goquery.Column(helper, "Text"),
+							""}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 1027}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? != ?",
+						[]any{
+							goquery.Column(helper, "Text"),
+							"c"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 1040}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? != ?",
+						[]any{
+							goquery.Column(helper, "Text"),
+							"b"}...)
+				},
+			},
+			OrderBy: map[goquery.Caller]goquery.ExprFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 636}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "Priority")}
+				},
+			},
+		},
+	)
+
+	goquery.AddToGlobalEntity[*extensiveDTO](
+		goquery.Calls{
+			OrderBy: map[goquery.Caller]goquery.ExprFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 816}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "Total")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 839}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "Total")}
+				},
+			},
+		},
+	)
+
+	goquery.AddToGlobalEntity[*extensiveStats](
+		goquery.Calls{
+			OrderBy: map[goquery.Caller]goquery.ExprFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/addables_test.go", Line: 883}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "Name")}
+				},
+			},
+		},
+	)
+}
@@ -5,18 +5,24 @@ package internal_test
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"math"
 	"os"
+	rt "runtime"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
 	"github.com/uptrace/bun/dialect/sqlitedialect"
 	"github.com/uptrace/bun/driver/sqliteshim"
+	"github.com/uptrace/bun/schema"
 
 	"github.com/ffenix113/goquery"
+	"github.com/ffenix113/goquery/goquerytest"
 )
 
 type Extensive struct {
@@ -28,6 +34,11 @@ type Extensive struct {
 
 const packageConst = 1
 
+var (
+	packageVar    = 5
+	packageConfig = struct{ Name string }{Name: "config"}
+)
+
 func TestSimpleAddables(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -74,6 +85,117 @@ func TestSimpleAddables(t *testing.T) {
 			},
 			result: `("string_col" IN ('1', '2'))`,
 		},
+		{
+			name: "in with other arguments",
+			f: func(q goquery.Queryable[*Extensive]) {
+				prefix, stringArgs := "x", []string{"1", "2"}
+				q.Where(func(e *Extensive) bool {
+					return e.StringCol2 == prefix && goquery.In(e.StringCol, stringArgs)
+				}, prefix, stringArgs)
+			},
+			result: `("string_col2" = 'x' AND "string_col" IN ('1', '2'))`,
+		},
+		{
+			name: "package variables",
+			f: func(q goquery.Queryable[*Extensive]) {
+				q.Where(func(e *Extensive) bool {
+					return e.IntCol > packageVar && e.StringCol == packageConfig.Name
+				})
+			},
+			result: `("int_col" > 5 AND "string_col" = 'config')`,
+		},
+		{
+			name: "variable of renamed package",
+			f: func(q goquery.Queryable[*Extensive]) {
+				q.Where(func(e *Extensive) bool {
+					return e.IntCol < rt.MemProfileRate
+				})
+			},
+			result: `("int_col" < 524288)`,
+		},
+		{
+			name: "shadowed argument",
+			f: func(q goquery.Queryable[*Extensive]) {
+				q.Where(func(e *Extensive) bool {
+					return e.IntCol > packageVar
+				})
+
+				packageVar := 10
+				q.Where(func(e *Extensive) bool {
+					return e.IntCol < packageVar
+				}, packageVar)
+			},
+			result: `("int_col" > 5) AND ("int_col" < 10)`,
+		},
+		{
+			name: "expression arguments",
+			f: func(q goquery.Queryable[*Extensive]) {
+				ids := []int{3, 4}
+				limit := int64(7)
+				q.Where(func(e *Extensive) bool {
+					return e.IntCol == ids[1] || e.StringCol == strings.Repeat("a", ids[0]) ||
+						goquery.In(e.IntCol, []int{ids[0], 5}) || e.IntCol < int(limit)
+				}, ids[1], strings.Repeat("a", ids[0]), []int{ids[0], 5}, int(limit))
+			},
+			result: `("int_col" = 4 OR "string_col" = 'aaa' OR "int_col" IN (3, 5) OR "int_col" < 7)`,
+		},
+		{
+			name: "captured variables",
+			f: func(q goquery.Queryable[*Extensive]) {
+				stringArg, intVar := "arg", 88
+				q.Where(func(e *Extensive) bool {
+					return e.StringCol == stringArg || e.StringCol == e.StringCol2 && e.IntCol >= intVar
+				})
+			},
+			result: `("string_col" = 'arg' OR "string_col" = "string_col2" AND "int_col" >= 88)`,
+		},
+		{
+			name: "captured fields and elements",
+			f: func(q goquery.Queryable[*Extensive]) {
+				filter := struct {
+					Name string
+					IDs  []int
+				}{Name: "config", IDs: []int{3, 4}}
+				q.Where(func(e *Extensive) bool {
+					return e.StringCol == filter.Name && e.IntCol != filter.IDs[1] || goquery.In(e.IntCol, filter.IDs)
+				})
+			},
+			result: `("string_col" = 'config' AND "int_col" != 4 OR "int_col" IN (3, 4))`,
+		},
+		{
+			name: "captured variables with arguments",
+			f: func(q goquery.Queryable[*Extensive]) {
+				prefix, limit := "x", 7
+				q.Where(func(e *Extensive) bool {
+					return e.StringCol2 == prefix && e.IntCol < limit && e.StringCol != prefix
+				}, limit)
+			},
+			result: `("string_col2" = 'x' AND "int_col" < 7 AND "string_col" != 'x')`,
+		},
+		{
+			name: "captured by reference",
+			f: func(q goquery.Queryable[*Extensive]) {
+				limit, cursor := 1, 3
+				next := &cursor
+				*next++
+				q.Where(func(e *Extensive) bool {
+					return e.IntCol > limit && e.IntCol != cursor
+				})
+				limit++
+			},
+			result: `("int_col" > 1 AND "int_col" != 4)`,
+		},
+		{
+			name: "captured loop variables",
+			f: func(q goquery.Queryable[*Extensive]) {
+				for _, name := range []string{"a", "b"} {
+					for i := 0; i < 1; i++ {
+						q.Where(func(e *Extensive) bool { return e.StringCol == name && e.IntCol > i })
+					}
+				}
+			},
+			result: `("string_col" = 'a' AND "int_col" > 0) AND ("string_col" = 'b' AND "int_col" > 0)`,
+		},
 		{
 			name: "is null",
 			f: func(q goquery.Queryable[*Extensive]) {
@@ -99,7 +221,16 @@ func TestSimpleAddables(t *testing.T) {
 					return e.TimeCol.Add(-3*time.Second) == time.Now()
 				})
 			},
-			result: `("time_col" + -3 * INTERVAL '1 second' = NOW())`,
+			result: `((rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', "time_col", (-3) || ' seconds'), '0'), '.') || '+00:00') = (rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', 'now'), '0'), '.') || '+00:00'))`,
+		},
+		{
+			name: "standalone duration",
+			f: func(q goquery.Queryable[*Extensive]) {
+				q.Where(func(e *Extensive) bool {
+					return time.Duration(e.IntCol) < 2*time.Second
+				})
+			},
+			result: `("int_col" < 2 * 1000000000)`,
 		},
 		{
 			name: "with const simple",
@@ -138,6 +269,53 @@ func TestSimpleAddables(t *testing.T) {
 			},
 			result: `("int_col" = 127)`,
 		},
+		{
+			name: "local variables",
+			f: func(q goquery.Queryable[*Extensive]) {
+				q.Where(func(e *Extensive) bool {
+					name := strings.ToUpper(e.StringCol)
+					next := e.IntCol + 1
+					cutoff := time.Now().Add(-24 * time.Hour)
+					return name == "A" && next*2 > 4 && e.TimeCol.After(cutoff)
+				})
+			},
+			result: `(upper("string_col") = 'A' AND ("int_col" + 1) * 2 > 4 AND "time_col" > (rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', (rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', 'now'), '0'), '.') || '+00:00'), (-24) || ' hours'), '0'), '.') || '+00:00'))`,
+		},
+		{
+			name: "early returns",
+			f: func(q goquery.Queryable[*Extensive]) {
+				q.Where(func(e *Extensive) bool {
+					if e.IntCol < 0 {
+						return false
+					}
+
+					if name := e.StringCol; name == "a" {
+						return true
+					} else if name == "b" {
+						return e.IntCol > 5
+					}
+
+					return e.StringCol2 != ""
+				})
+			},
+			result: `(not ("int_col" < 0) AND (("string_col" = 'a') OR (("string_col" = 'b') AND ("int_col" > 5) OR not ("string_col" = 'b') AND ("string_col2" != ''))))`,
+		},
+		{
+			name: "switch",
+			f: func(q goquery.Queryable[*Extensive]) {
+				q.Where(func(e *Extensive) bool {
+					switch e.StringCol {
+					case "gold", "silver":
+						return e.IntCol > 1000
+					case "basic":
+						return false
+					default:
+						return e.StringCol2 != ""
+					}
+				})
+			},
+			result: `((("string_col" = 'gold') OR ("string_col" = 'silver')) AND ("int_col" > 1000) OR not (("string_col" = 'gold') OR ("string_col" = 'silver')) AND (not ("string_col" = 'basic') AND ("string_col2" != '')))`,
+		},
 	}
 
 	for _, test := range tests {
@@ -150,16 +328,753 @@ func TestSimpleAddables(t *testing.T) {
 			q := factory.New()
 			test.f(q)
 
-			var wrapper iconnWrapper
+			var wrapper goquerytest.Conn
 			_, err := q.Query().Conn(&wrapper).Exec(context.Background())
 			require.NoError(t, err)
 
-			assert.Equal(t, test.result, wrapper.query[len("select * where "):])
-			assert.Equal(t, test.args, wrapper.args)
+			assert.Equal(t, test.result, wrapper.Query[len("select * where "):])
+			assert.Equal(t, test.args, wrapper.Args)
 		})
 	}
 }
 
+func TestDialects(t *testing.T) {
+	tests := []struct {
+		dialect dialect.Name
+		result  string
+	}{
+		{
+			dialect: dialect.PG,
+			result: `WHERE ("string_col" || 'x' LIKE '%' || "string_col2" || '%') ` +
+				`AND ("time_col" + 2 * INTERVAL '1 hour' > NOW() AND "time_col" + INTERVAL '1 millisecond' < NOW()) ` +
+				`AND (CAST("int_col" AS DOUBLE PRECISION) > 1.5 AND CAST(TRUNC(CAST("int_col" AS DOUBLE PRECISION) / 2) AS BIGINT) = 1) ` +
+				`AND (not ("string_col" = 'a') OR ("int_col" > 1))`,
+		},
+		{
+			dialect: dialect.SQLite,
+			result: `WHERE ("string_col" || 'x' LIKE '%' || "string_col2" || '%') ` +
+				`AND ((rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', "time_col", (2) || ' hours'), '0'), '.') || '+00:00') > (rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', 'now'), '0'), '.') || '+00:00') AND (rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', "time_col", (1 / 1000.0) || ' seconds'), '0'), '.') || '+00:00') < (rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', 'now'), '0'), '.') || '+00:00')) ` +
+				`AND (CAST("int_col" AS DOUBLE PRECISION) > 1.5 AND CAST(CAST("int_col" AS DOUBLE PRECISION) / 2 AS INTEGER) = 1) ` +
+				`AND (not ("string_col" = 'a') OR ("int_col" > 1))`,
+		},
+		{
+			dialect: dialect.MySQL,
+			result: `WHERE (CONCAT("string_col", 'x') LIKE CONCAT('%', "string_col2", '%')) ` +
+				`AND (DATE_ADD("time_col", INTERVAL 2 HOUR) > NOW() AND DATE_ADD("time_col", INTERVAL (1) * 1000 MICROSECOND) < NOW()) ` +
+				`AND (CAST("int_col" AS DOUBLE) > 1.5 AND CAST(TRUNCATE(CAST("int_col" AS DOUBLE) / 2, 0) AS SIGNED) = 1) ` +
+				`AND (not ("string_col" = 'a') OR ("int_col" > 1))`,
+		},
+		{
+			dialect: dialect.MSSQL,
+			result: `WHERE (CONCAT("string_col", 'x') LIKE CONCAT('%', "string_col2", '%')) ` +
+				`AND (DATEADD(hour, 2, "time_col") > SYSDATETIME() AND DATEADD(millisecond, 1, "time_col") < SYSDATETIME()) ` +
+				`AND (CAST("int_col" AS FLOAT) > 1.5 AND CAST(CAST("int_col" AS FLOAT) / 2 AS BIGINT) = 1) ` +
+				`AND (not ("string_col" = 'a') OR ("int_col" > 1))`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.dialect.String(), func(t *testing.T) {
+			db := bun.NewDB(getDB(t).DB, namedDialect{Dialect: sqlitedialect.New(), name: test.dialect})
+
+			q := goquery.NewFactory[*Extensive](db).New().
+				Where(func(e *Extensive) bool { return strings.Contains(e.StringCol+"x", e.StringCol2) }).
+				Where(func(e *Extensive) bool {
+					return e.TimeCol.Add(2*time.Hour).After(time.Now()) && e.TimeCol.Add(time.Millisecond).Before(time.Now())
+				}).
+				Where(func(e *Extensive) bool { return float64(e.IntCol) > 1.5 && int(float64(e.IntCol)/2) == 1 }).
+				Where(func(e *Extensive) bool {
+					switch e.StringCol {
+					case "a":
+						return e.IntCol > 1
+					}
+
+					return true
+				})
+
+			var wrapper goquerytest.Conn
+			_, err := q.Query().Conn(&wrapper).Exec(context.Background())
+			require.NoError(t, err)
+
+			assert.Equal(t, test.result, wrapper.Query[len("SELECT * "):])
+		})
+	}
+}
+
+// namedDialect only changes name of the dialect,
+// so queries are rendered for it.
+type namedDialect struct {
+	schema.Dialect
+	name dialect.Name
+}
+
+func (d namedDialect) Name() dialect.Name {
+	return d.name
+}
+
+func TestOrderBy(t *testing.T) {
+	tests := []struct {
+		name   string
+		f      func(q goquery.Queryable[*Extensive])
+		result string
+		args   []any
+	}{
+		{
+			name: "single column",
+			f: func(q goquery.Queryable[*Extensive]) {
+				q.OrderBy(func(e *Extensive) any { return e.StringCol })
+			},
+			result: `ORDER BY "string_col" ASC`,
+		},
+		{
+			name: "then by",
+			f: func(q goquery.Queryable[*Extensive]) {
+				q.OrderByDescending(func(e *Extensive) any { return e.IntCol }).
+					ThenBy(func(e *Extensive) any { return e.StringCol }).
+					ThenByDescending(func(e *Extensive) any { return e.TimeCol })
+			},
+			result: `ORDER BY "int_col" DESC, "string_col" ASC, "time_col" DESC`,
+		},
+		{
+			name: "expression",
+			f: func(q goquery.Queryable[*Extensive]) {
+				factor := 2
+				q.OrderBy(func(e *Extensive) any {
+					return e.IntCol * factor
+				}, factor)
+			},
+			result: `ORDER BY "int_col" * 2 ASC`,
+		},
+		{
+			name: "captured variables",
+			f: func(q goquery.Queryable[*Extensive]) {
+				factor, offset := 2, 1
+				q.OrderBy(func(e *Extensive) any { return e.IntCol * factor }).
+					ThenByDescending(func(e *Extensive) any { return e.IntCol + offset })
+			},
+			result: `ORDER BY "int_col" * 2 ASC, "int_col" + 1 DESC`,
+		},
+		{
+			name: "conditional",
+			f: func(q goquery.Queryable[*Extensive]) {
+				q.OrderBy(func(e *Extensive) any {
+					if e.IntCol > 10 {
+						return e.StringCol
+					} else if e.IntCol > 5 {
+						return e.StringCol2
+					}
+
+					return ""
+				})
+			},
+			result: `ORDER BY CASE WHEN "int_col" > 10 THEN "string_col" WHEN "int_col" > 5 THEN "string_col2" ELSE '' END ASC`,
+		},
+		{
+			name: "with where",
+			f: func(q goquery.Queryable[*Extensive]) {
+				q.Where(func(e *Extensive) bool { return e.IntCol > 1 }).
+					OrderBy(func(e *Extensive) any { return strings.ToLower(e.StringCol) })
+			},
+			result: `WHERE ("int_col" > 1) ORDER BY lower("string_col") ASC`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		db := getDB(t)
+
+		factory := goquery.NewFactory[*Extensive](db)
+
+		t.Run(test.name, func(t *testing.T) {
+			q := factory.New()
+			test.f(q)
+
+			var wrapper goquerytest.Conn
+			_, err := q.Query().Conn(&wrapper).Exec(context.Background())
+			require.NoError(t, err)
+
+			assert.Equal(t, test.result, wrapper.Query[len("SELECT * "):])
+			assert.Equal(t, test.args, wrapper.Args)
+		})
+	}
+}
+
+func TestOrderByMisuse(t *testing.T) {
+	q := goquery.NewFactory[*Extensive](getDB(t)).New()
+
+	assert.Panics(t, func() {
+		q.ThenBy(func(e *Extensive) any { return e.IntCol })
+	})
+
+	q.OrderBy(func(e *Extensive) any { return e.IntCol })
+
+	assert.Panics(t, func() {
+		q.OrderBy(func(e *Extensive) any { return e.StringCol })
+	})
+}
+
+func TestSameLineCalls(t *testing.T) {
+	factory := goquery.NewFactory[*Extensive](getDB(t))
+
+	query := func(q goquery.Queryable[*Extensive]) string {
+		var wrapper goquerytest.Conn
+		_, err := q.Query().Conn(&wrapper).Exec(context.Background())
+		require.NoError(t, err)
+
+		return wrapper.Query[len("SELECT * "):]
+	}
+
+	for _, minInt := range []int{1, 2} {
+		q := factory.New()
+		// Line is evaluated twice for the same queryable.
+		for range 2 {
+			q.Where(func(e *Extensive) bool { return e.IntCol > minInt }, minInt).Where(func(e *Extensive) bool { return e.StringCol != "a" })
+		}
+
+		assert.Equal(t, fmt.Sprintf(`WHERE ("int_col" > %[1]d) AND ("string_col" != 'a') AND ("int_col" > %[1]d) AND ("string_col" != 'a')`, minInt), query(q))
+	}
+
+	q := factory.New()
+	q.OrderBy(func(e *Extensive) any { return e.IntCol }).ThenByDescending(func(e *Extensive) any { return e.StringCol })
+
+	assert.Equal(t, `ORDER BY "int_col" ASC, "string_col" DESC`, query(q))
+
+	// Calls of other entities on the line are not counted.
+	notes := goquery.NewFactory[*Note](getDB(t)).New()
+	q = factory.New()
+	for range 2 {
+		sameLine(q.Where(func(e *Extensive) bool { return e.IntCol > 1 }).Where(func(e *Extensive) bool { return e.IntCol < 5 }), notes.Where(func(n *Note) bool { return n.Text != "" }))
+	}
+
+	assert.Equal(t, `WHERE ("int_col" > 1) AND ("int_col" < 5) AND ("int_col" > 1) AND ("int_col" < 5)`, query(q))
+}
+
+func sameLine(...any) {}
+
+func TestPaging(t *testing.T) {
+	cursor := &Extensive{StringCol: "cursor", IntCol: 10}
+
+	tests := []struct {
+		name   string
+		f      func(q goquery.Queryable[*Extensive])
+		result string
+	}{
+		{
+			name: "skip and take",
+			f: func(q goquery.Queryable[*Extensive]) {
+				q.OrderBy(func(e *Extensive) any { return e.IntCol }).Skip(20).Take(10)
+			},
+			result: `ORDER BY "int_col" ASC LIMIT 10 OFFSET 20`,
+		},
+		{
+			name: "after single key",
+			f: func(q goquery.Queryable[*Extensive]) {
+				q.OrderByDescending(func(e *Extensive) any { return e.IntCol }).After(cursor).Take(10)
+			},
+			result: `WHERE ("int_col" < 10) ORDER BY "int_col" DESC LIMIT 10`,
+		},
+		{
+			name: "after same direction",
+			f: func(q goquery.Queryable[*Extensive]) {
+				q.OrderBy(func(e *Extensive) any { return e.StringCol }).
+					ThenBy(func(e *Extensive) any { return e.IntCol }).
+					After(cursor)
+			},
+			result: `WHERE (("string_col", "int_col") > ('cursor', 10)) ORDER BY "string_col" ASC, "int_col" ASC`,
+		},
+		{
+			name: "after mixed direction",
+			f: func(q goquery.Queryable[*Extensive]) {
+				q.OrderBy(func(e *Extensive) any { return e.StringCol }).
+					ThenByDescending(func(e *Extensive) any { return e.IntCol }).
+					After(cursor)
+			},
+			result: `WHERE ("string_col" > 'cursor' OR "string_col" = 'cursor' AND "int_col" < 10) ORDER BY "string_col" ASC, "int_col" DESC`,
+		},
+		{
+			name: "after expression key",
+			f: func(q goquery.Queryable[*Extensive]) {
+				q.Where(func(e *Extensive) bool { return e.IntCol > 0 }).
+					OrderBy(func(e *Extensive) any { return strings.ToUpper(e.StringCol) }).
+					After(cursor)
+			},
+			result: `WHERE ("int_col" > 0) AND (upper("string_col") > 'CURSOR') ORDER BY upper("string_col") ASC`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		db := getDB(t)
+
+		factory := goquery.NewFactory[*Extensive](db)
+
+		t.Run(test.name, func(t *testing.T) {
+			q := factory.New()
+			test.f(q)
+
+			var wrapper goquerytest.Conn
+			_, err := q.Query().Conn(&wrapper).Exec(context.Background())
+			require.NoError(t, err)
+
+			assert.Equal(t, test.result, wrapper.Query[len("SELECT * "):])
+		})
+	}
+}
+
+func TestAfterWithoutOrder(t *testing.T) {
+	q := goquery.NewFactory[*Extensive](getDB(t)).New()
+
+	assert.Panics(t, func() {
+		q.After(&Extensive{})
+	})
+}
+
+func TestAfterNullCursor(t *testing.T) {
+	q := goquery.NewFactory[*Note](getDB(t)).New().
+		OrderBy(func(n *Note) any { return n.Priority })
+
+	assert.PanicsWithValue(t, "After cursor has NULL value of ordering key 0, rows cannot be placed after it", func() {
+		q.After(&Note{Text: "a"})
+	})
+}
+
+func TestTerminalOperators(t *testing.T) {
+	ctx := context.Background()
+	db := getDB(t)
+
+	createExtensiveTable(t, db,
+		&Extensive{StringCol: "a", IntCol: 1},
+		&Extensive{StringCol: "b", IntCol: 2},
+		&Extensive{StringCol: "c", IntCol: 3},
+	)
+
+	factory := goquery.NewFactory[*Extensive](db)
+
+	t.Run("to slice", func(t *testing.T) {
+		res, err := factory.New().
+			Where(func(e *Extensive) bool { return e.IntCol > 1 }).
+			OrderByDescending(func(e *Extensive) any { return e.IntCol }).
+			ToSlice(ctx)
+		require.NoError(t, err)
+		require.Len(t, res, 2)
+		assert.Equal(t, "c", res[0].StringCol)
+		assert.Equal(t, "b", res[1].StringCol)
+	})
+
+	t.Run("first", func(t *testing.T) {
+		q := factory.New().OrderBy(func(e *Extensive) any { return e.IntCol })
+
+		res, err := q.First(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "a", res.StringCol)
+
+		// Terminal operators must not change the query.
+		all, err := q.ToSlice(ctx)
+		require.NoError(t, err)
+		assert.Len(t, all, 3)
+
+		_, err = factory.New().Where(func(e *Extensive) bool { return e.IntCol > 10 }).First(ctx)
+		assert.ErrorIs(t, err, goquery.ErrNoRows)
+
+		res, err = factory.New().Where(func(e *Extensive) bool { return e.IntCol > 10 }).FirstOrDefault(ctx)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	})
+
+	t.Run("single", func(t *testing.T) {
+		res, err := factory.New().Where(func(e *Extensive) bool { return e.StringCol == "b" }).Single(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, res.IntCol)
+
+		_, err = factory.New().Where(func(e *Extensive) bool { return e.StringCol == "d" }).Single(ctx)
+		assert.ErrorIs(t, err, goquery.ErrNoRows)
+
+		_, err = factory.New().Where(func(e *Extensive) bool { return e.IntCol < 3 }).Single(ctx)
+		assert.ErrorIs(t, err, goquery.ErrMultipleRows)
+
+		// Take limits rows Single checks.
+		res, err = factory.New().OrderBy(func(e *Extensive) any { return e.IntCol }).Take(1).Single(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "a", res.StringCol)
+	})
+
+	t.Run("count and any", func(t *testing.T) {
+		count, err := factory.New().Where(func(e *Extensive) bool { return e.IntCol >= 2 }).Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		exists, err := factory.New().Where(func(e *Extensive) bool { return e.StringCol == "c" }).Any(ctx)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = factory.New().Where(func(e *Extensive) bool { return e.StringCol == "d" }).Any(ctx)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		// Paging applies to all terminal operators.
+		page := factory.New().OrderBy(func(e *Extensive) any { return e.IntCol }).Skip(1).Take(5)
+		count, err = page.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+
+		exists, err = page.Skip(3).Any(ctx)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("all", func(t *testing.T) {
+		all, err := factory.New().All(ctx, func(e *Extensive) bool { return e.IntCol > 0 })
+		require.NoError(t, err)
+		assert.True(t, all)
+
+		minVal := 2
+		all, err = factory.New().All(ctx, func(e *Extensive) bool { return e.IntCol >= minVal }, minVal)
+		require.NoError(t, err)
+		assert.False(t, all)
+
+		all, err = factory.New().
+			Where(func(e *Extensive) bool { return e.IntCol >= 2 }).
+			All(ctx, func(e *Extensive) bool { return e.IntCol >= minVal }, minVal)
+		require.NoError(t, err)
+		assert.True(t, all)
+
+		// Rows for which the filter is NULL do not satisfy it.
+		_, err = db.NewInsert().Model(&Extensive{IntCol: 4}).Value("string_col", "NULL").Exec(ctx)
+		require.NoError(t, err)
+
+		all, err = factory.New().
+			Where(func(e *Extensive) bool { return e.IntCol >= 2 }).
+			All(ctx, func(e *Extensive) bool { return e.StringCol != "a" })
+		require.NoError(t, err)
+		assert.False(t, all)
+	})
+}
+
+type extensiveDTO struct {
+	Name  string
+	Upper string
+	Total int
+}
+
+func TestSelect(t *testing.T) {
+	ctx := context.Background()
+	db := getDB(t)
+
+	createExtensiveTable(t, db,
+		&Extensive{StringCol: "a", StringCol2: "x", IntCol: 1},
+		&Extensive{StringCol: "b", StringCol2: "y", IntCol: 2},
+	)
+
+	factory := goquery.NewFactory[*Extensive](db)
+
+	t.Run("struct", func(t *testing.T) {
+		q := factory.New().Where(func(e *Extensive) bool { return e.IntCol > 1 })
+
+		res, err := goquery.Select(q, func(e *Extensive) extensiveDTO {
+			return extensiveDTO{
+				Name:  e.StringCol,
+				Upper: strings.ToUpper(e.StringCol2),
+				Total: e.IntCol * e.IntCol,
+			}
+		}).ToSlice(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []extensiveDTO{{Name: "b", Upper: "Y", Total: 4}}, res)
+	})
+
+	t.Run("query", func(t *testing.T) {
+		multiplier := 10
+		q := goquery.Select(factory.New(), func(e *Extensive) *extensiveDTO {
+			return &extensiveDTO{Name: e.StringCol, Total: e.IntCol * multiplier}
+		}, multiplier)
+
+		var wrapper goquerytest.Conn
+		_, err := q.Query().Conn(&wrapper).Exec(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, `SELECT "string_col" AS "name", "int_col" * 10 AS "total" FROM "extensives" AS "extensive"`, wrapper.Query)
+	})
+
+	t.Run("captured", func(t *testing.T) {
+		suffix := "!"
+		res, err := goquery.Select(factory.New(), func(e *Extensive) extensiveDTO {
+			return extensiveDTO{Name: e.StringCol + suffix}
+		}).ToSlice(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []extensiveDTO{{Name: "a!"}, {Name: "b!"}}, res)
+	})
+
+	t.Run("conditional", func(t *testing.T) {
+		q := goquery.Select(factory.New(), func(e *Extensive) extensiveDTO {
+			switch {
+			case e.IntCol > 1:
+				return extensiveDTO{Name: e.StringCol, Total: e.IntCol * 10}
+			}
+
+			return extensiveDTO{Total: e.IntCol, Name: "small"}
+		}).OrderBy(func(d extensiveDTO) any { return d.Total })
+
+		res, err := q.ToSlice(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []extensiveDTO{{Name: "small", Total: 1}, {Name: "b", Total: 20}}, res)
+	})
+
+	t.Run("anonymous struct", func(t *testing.T) {
+		q := goquery.Select(factory.New(), func(e *Extensive) struct{ Name string } {
+			return struct{ Name string }{e.StringCol}
+		})
+
+		res, err := q.First(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "a", res.Name)
+
+		count, err := q.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("same line", func(t *testing.T) {
+		// Calls of the source and of the result are counted separately.
+		q := goquery.Select(factory.New().OrderBy(func(e *Extensive) any { return e.StringCol2 }), func(e *Extensive) extensiveDTO { return extensiveDTO{Name: e.StringCol, Total: e.IntCol} }).OrderBy(func(d extensiveDTO) any { return d.Total })
+
+		var wrapper goquerytest.Conn
+		_, err := q.Query().Conn(&wrapper).Exec(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, `SELECT "string_col" AS "name", "int_col" AS "total" FROM "extensives" AS "extensive" ORDER BY "string_col2" ASC, "total" ASC`, wrapper.Query)
+	})
+}
+
+type extensiveStats struct {
+	Name  string
+	Count int
+	Total float64
+	Avg   float64
+	Max   float64
+}
+
+func TestGroupBy(t *testing.T) {
+	ctx := context.Background()
+	db := getDB(t)
+
+	createExtensiveTable(t, db,
+		&Extensive{StringCol: "a", IntCol: 1},
+		&Extensive{StringCol: "a", IntCol: 3},
+		&Extensive{StringCol: "b", IntCol: 2},
+		&Extensive{StringCol: "c", IntCol: 5},
+		&Extensive{StringCol: "c", IntCol: 7},
+		&Extensive{StringCol: "c", IntCol: 9},
+	)
+
+	factory := goquery.NewFactory[*Extensive](db)
+
+	t.Run("aggregates", func(t *testing.T) {
+		groups := goquery.GroupBy(factory.New(), func(e *Extensive) string { return e.StringCol })
+
+		res, err := goquery.Select(groups, func(g goquery.Group[string, *Extensive]) extensiveStats {
+			return extensiveStats{
+				Name:  g.Key,
+				Count: g.Count(),
+				Total: g.Sum(func(e *Extensive) float64 { return float64(e.IntCol) }),
+				Avg:   g.Avg(func(e *Extensive) float64 { return float64(e.IntCol) }),
+				Max:   g.Max(func(e *Extensive) float64 { return float64(e.IntCol) }),
+			}
+		}).OrderBy(func(s extensiveStats) any { return s.Name }).ToSlice(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []extensiveStats{
+			{Name: "a", Count: 2, Total: 4, Avg: 2, Max: 3},
+			{Name: "b", Count: 1, Total: 2, Avg: 2, Max: 2},
+			{Name: "c", Count: 3, Total: 21, Avg: 7, Max: 9},
+		}, res)
+	})
+
+	t.Run("having", func(t *testing.T) {
+		minCount := 1
+		groups := goquery.GroupBy(factory.New().Where(func(e *Extensive) bool { return e.IntCol > 1 }),
+			func(e *Extensive) string { return strings.ToUpper(e.StringCol) }).
+			Where(func(g goquery.Group[string, *Extensive]) bool {
+				return g.Count() > minCount && g.Key != "B"
+			}, minCount)
+
+		q := goquery.Select(groups, func(g goquery.Group[string, *Extensive]) extensiveStats {
+			return extensiveStats{Name: g.Key, Count: g.Count()}
+		})
+
+		res, err := q.ToSlice(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []extensiveStats{{Name: "C", Count: 3}}, res)
+
+		var wrapper goquerytest.Conn
+		_, err = q.Query().Conn(&wrapper).Exec(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, `SELECT upper("string_col") AS "name", count(*) AS "count" FROM "extensives" AS "extensive" `+
+			`WHERE ("int_col" > 1) GROUP BY upper("string_col") HAVING (count(*) > 1 AND upper("string_col") != 'B')`, wrapper.Query)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := context.Background()
+	db := getDB(t)
+
+	createExtensiveTable(t, db,
+		&Extensive{StringCol: "a", IntCol: 1},
+		&Extensive{StringCol: "b", IntCol: 2},
+		&Extensive{StringCol: "c", IntCol: 3},
+	)
+
+	var hook queryHook
+	db.AddQueryHook(&hook)
+
+	factory := goquery.NewFactory[*Extensive](db)
+
+	factor := 10
+	updated, err := factory.New().
+		Where(func(e *Extensive) bool { return e.StringCol != "b" }).
+		Update(ctx, func(e *Extensive) {
+			e.IntCol = e.IntCol * factor
+			e.StringCol2 = strings.ToUpper(e.StringCol)
+		}, factor)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, updated)
+	assert.Equal(t, `UPDATE "extensives" AS "extensive" SET "int_col" = "int_col" * 10, "string_col2" = upper("string_col") `+
+		`WHERE ("string_col" != 'b')`, hook.query)
+
+	updated, err = factory.New().
+		Where(func(e *Extensive) bool { return e.IntCol > 20 }).
+		Where(func(e *Extensive) bool { return e.StringCol2 == "C" }).
+		Update(ctx, func(e *Extensive) {
+			e.IntCol += 2 - 1
+		})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, updated)
+	assert.Equal(t, `UPDATE "extensives" AS "extensive" SET "int_col" = "int_col" + (2 - 1) `+
+		`WHERE ("int_col" > 20) AND ("string_col2" = 'C')`, hook.query)
+
+	updated, err = factory.New().
+		Where(func(e *Extensive) bool { return e.StringCol == "a" }).
+		Update(ctx, func(e *Extensive) { e.IntCol-- })
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, updated)
+
+	suffix := "!"
+	updated, err = factory.New().
+		Where(func(e *Extensive) bool { return e.IntCol == factor+1 }).
+		Update(ctx, func(e *Extensive) { e.StringCol2 = e.StringCol2 + suffix })
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, updated)
+	assert.Equal(t, `UPDATE "extensives" AS "extensive" SET "string_col2" = "string_col2" || '!' WHERE ("int_col" = 10 + 1)`, hook.query)
+
+	res, err := factory.New().OrderBy(func(e *Extensive) any { return e.StringCol }).ToSlice(ctx)
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+	assert.Equal(t, 9, res[0].IntCol)
+	assert.Equal(t, "A", res[0].StringCol2)
+	assert.Equal(t, 2, res[1].IntCol)
+	assert.Equal(t, "", res[1].StringCol2)
+	assert.Equal(t, 31, res[2].IntCol)
+}
+
+type Note struct {
+	ID        int64 `bun:",pk,autoincrement"`
+	Text      string
+	Priority  *int
+	DeletedAt time.Time `bun:",soft_delete,nullzero"`
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.Background()
+	db := getDB(t)
+
+	createExtensiveTable(t, db,
+		&Extensive{StringCol: "a", IntCol: 1},
+		&Extensive{StringCol: "b", IntCol: 2},
+		&Extensive{StringCol: "c", IntCol: 3},
+	)
+
+	var hook queryHook
+	db.AddQueryHook(&hook)
+
+	factory := goquery.NewFactory[*Extensive](db)
+
+	deleted, err := factory.New().
+		Where(func(e *Extensive) bool { return e.IntCol >= 2 && e.StringCol != "c" }).
+		Delete(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, deleted)
+	assert.Equal(t, `DELETE FROM "extensives" AS "extensive" WHERE ("int_col" >= 2 AND "string_col" != 'c')`, hook.query)
+
+	count, err := factory.New().Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	t.Run("soft delete", func(t *testing.T) {
+		_, err := db.NewCreateTable().Model((*Note)(nil)).Exec(ctx)
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			_, err := db.NewDropTable().Model((*Note)(nil)).Exec(ctx)
+			require.NoError(t, err)
+		})
+
+		notes := []*Note{{Text: "a"}, {Text: "b"}, {Text: "c"}}
+		_, err = db.NewInsert().Model(&notes).Exec(ctx)
+		require.NoError(t, err)
+
+		notesFactory := goquery.NewFactory[*Note](db)
+
+		deleted, err := notesFactory.New().Where(func(n *Note) bool { return n.Text != "c" }).Delete(ctx)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, deleted)
+
+		res, err := notesFactory.New().ToSlice(ctx)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, "c", res[0].Text)
+
+		total, err := db.NewSelect().Model((*Note)(nil)).WhereAllWithDeleted().Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+
+		deleted, err = notesFactory.New().Where(func(n *Note) bool { return n.Text != "b" }).ForceDelete(ctx)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, deleted)
+
+		total, err = db.NewSelect().Model((*Note)(nil)).WhereAllWithDeleted().Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+	})
+}
+
+// queryHook keeps the last executed query.
+type queryHook struct {
+	query string
+}
+
+func (h *queryHook) BeforeQuery(ctx context.Context, _ *bun.QueryEvent) context.Context {
+	return ctx
+}
+
+func (h *queryHook) AfterQuery(_ context.Context, event *bun.QueryEvent) {
+	h.query = event.Query
+}
+
+func createExtensiveTable(t testing.TB, db *bun.DB, rows ...*Extensive) {
+	ctx := context.Background()
+
+	_, err := db.NewCreateTable().Model((*Extensive)(nil)).Exec(ctx)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_, err := db.NewDropTable().Model((*Extensive)(nil)).Exec(ctx)
+		require.NoError(t, err)
+	})
+
+	_, err = db.NewInsert().Model(&rows).Exec(ctx)
+	require.NoError(t, err)
+}
+
 func getDB(t testing.TB) *bun.DB {
 	dbSource := os.Getenv("DB_SOURCE")
 	if dbSource == "" {
@@ -178,16 +1093,3 @@ func getDB(t testing.TB) *bun.DB {
 
 	return bun.NewDB(sqldb, sqlitedialect.New())
 }
-
-type iconnWrapper struct {
-	bun.IConn
-	query string
-	args  []any
-}
-
-func (w *iconnWrapper) ExecContext(_ context.Context, query string, args ...any) (sql.Result, error) {
-	w.query = query
-	w.args = args
-
-	return nil, nil
-}
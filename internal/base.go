@@ -3,7 +3,7 @@ package internal
 import (
 	"bytes"
 	"embed"
-	"os"
+	"fmt"
 	"strings"
 	"text/template"
 
@@ -17,35 +17,25 @@ var funcMap = template.FuncMap{
 	"join": strings.Join,
 }
 
-func WriteBase(c *Context, goFilePath string) {
+// RenderBase returns formatted content of the generated file.
+func RenderBase(c *Context) ([]byte, error) {
 	baseTpl, err := template.New("base.tpl").Funcs(funcMap).ParseFS(baseTplFS, "base.tpl")
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("parse template: %w", err)
 	}
 
 	var outputBuf bytes.Buffer
 
 	if err := baseTpl.Execute(&outputBuf, c); err != nil {
-		panic(err)
+		return nil, fmt.Errorf("execute template: %w", err)
 	}
 
 	processed, err := imports.Process("", outputBuf.Bytes(), nil)
 	if err != nil {
-		_, _ = outputBuf.WriteTo(os.Stdout)
-		panic(err)
-	}
-
-	baseFile, err := os.OpenFile(createdBaseTplFilePath(goFilePath), os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0644)
-	if err != nil {
-		panic(err)
-	}
-
-	defer baseFile.Close()
-
-	if _, err := baseFile.Write(processed); err != nil {
-		panic(err)
+		return nil, fmt.Errorf("format generated file: %w", err)
 	}
 
+	return processed, nil
 }
 
 func createdBaseTplFilePath(goFilePath string) string {
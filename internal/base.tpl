@@ -1,20 +1,116 @@
 // Code generated by goquery; DO NOT EDIT.
 
 package {{.PackageName}}
+{{if .Imports}}
+import (
+{{- range $path, $name := .Imports}}
+    {{$name}} "{{$path}}"
+{{- end}}
+)
+{{end}}
+{{define "query" -}}
+{{if .DialectQueries}}goquery.DialectQuery(query, "{{.Query}}", map[string]string{
+        {{- range $dialect, $query := .DialectQueries}}
+            "{{$dialect}}": "{{$query}}",
+        {{- end}}
+        }){{else}}"{{.Query}}"{{end}}
+{{- end}}
 
+{{define "args" -}}
+{{if .DialectArgs}}goquery.DialectArgs(query, []any{
+            {{join .Args ", \n"}}}, map[string][]any{
+        {{- range $dialect, $args := .DialectArgs}}
+            "{{$dialect}}": {
+            {{join $args ", \n"}}},
+        {{- end}}
+        }){{else}}[]any{
+            {{join .Args ", \n"}}}{{end}}
+{{- end}}
+
+{{define "exprFuncs" -}}
+map[goquery.Caller]goquery.ExprFunc{
+        {{- range $caller, $query := .}}
+            goquery.Caller{File: "{{$caller.Filename}}", Line: {{$caller.Line}}{{if $caller.Index}}, Index: {{$caller.Index}}{{end}}}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+            return {{template "query" $query}}, {{template "args" $query}}
+            },
+        {{end -}}
+        }
+{{- end}}
 
 func init() {
-{{- range $EntityTypeName, $Callers := .Data }}
+    goquery.RegisterPackagePath("{{.PackagePath}}")
+{{- range $EntityTypeName, $Calls := .Data }}
     goquery.AddToGlobalEntity[*{{$EntityTypeName}}](
         goquery.Calls{
+        {{- with index $Calls "Where"}}
         Where: map[goquery.Caller]goquery.QueryFunc{
-        {{- range $caller, $query := $Callers}}
-            goquery.Caller{File: "{{$caller.Filename}}", Line: {{$caller.Line}}}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) {
-            query.Where("{{$query.Query}}",
-            {{join $query.Args ", \n"}})
+        {{- range $caller, $query := .}}
+            goquery.Caller{File: "{{$caller.Filename}}", Line: {{$caller.Line}}{{if $caller.Index}}, Index: {{$caller.Index}}{{end}}}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+            {{if eq $query.Clause "Having"}}query.Unwrap().(*bun.SelectQuery){{else}}query{{end}}.{{$query.Clause}}({{template "query" $query}},
+            {{template "args" $query}}...)
+            },
+        {{end -}}
+        },
+        {{- end}}
+        {{- with index $Calls "OrderBy"}}
+        OrderBy: {{template "exprFuncs" .}},
+        {{- end}}
+        {{- with index $Calls "Select"}}
+        Select: map[goquery.Caller]goquery.ProjectionFunc{
+        {{- range $caller, $query := .}}
+            goquery.Caller{File: "{{$caller.Filename}}", Line: {{$caller.Line}}{{if $caller.Index}}, Index: {{$caller.Index}}{{end}}}: func(helper goquery.Helper, resultHelper goquery.Helper, query *bun.SelectQuery, args ...any) {
+            query.ColumnExpr({{template "query" $query}},
+            {{template "args" $query}}...)
             },
         {{end -}}
         },
+        {{- end}}
+        {{- with index $Calls "GroupBy"}}
+        GroupBy: {{template "exprFuncs" .}},
+        {{- end}}
+        {{- with index $Calls "Include"}}
+        Include: map[goquery.Caller]goquery.QueryFunc{
+        {{- range $caller, $query := .}}
+            goquery.Caller{File: "{{$caller.Filename}}", Line: {{$caller.Line}}{{if $caller.Index}}, Index: {{$caller.Index}}{{end}}}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+            goquery.IncludeRelation(helper, query, "{{$query.Relation}}",
+            {{- if $query.Query}} func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+                query.Where({{template "query" $query}},
+                {{template "args" $query}}...)
+            }{{else}} nil{{end}}, args...)
+            },
+        {{end -}}
+        },
+        {{- end}}
+        {{- with index $Calls "Update"}}
+        Update: map[goquery.Caller]goquery.UpdateFunc{
+        {{- range $caller, $query := .}}
+            goquery.Caller{File: "{{$caller.Filename}}", Line: {{$caller.Line}}{{if $caller.Index}}, Index: {{$caller.Index}}{{end}}}: func(helper goquery.Helper, query *bun.UpdateQuery, args ...any) {
+            query.Set({{template "query" $query}},
+            {{template "args" $query}}...)
+            },
+        {{end -}}
+        },
+        {{- end}}
+        {{- with $Calls.Captures}}
+        Captures: map[string]map[goquery.Caller]goquery.CaptureFunc{
+        {{- range $field, $closures := .}}
+            "{{$field}}": {
+            {{- range $caller, $closure := $closures}}
+                goquery.Caller{File: "{{$caller.Filename}}", Line: {{$caller.Line}}{{if $caller.Index}}, Index: {{$caller.Index}}{{end}}}: func(fn any) []any {
+                closure := (*struct {
+                    F uintptr
+                    {{- range $closure.Fields}}
+                    {{.}}
+                    {{- end}}
+                })(goquery.Closure(fn))
+
+                return []any{ {{- join $closure.Values ", "}}}
+                },
+            {{- end}}
+            },
+        {{- end}}
+        },
+        {{- end}}
         },
     )
 {{ end -}}
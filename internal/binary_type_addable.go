@@ -2,6 +2,7 @@ package internal
 
 import (
 	"go/ast"
+	"go/token"
 )
 
 var binaryTypeGenerators = map[[2]string]typedGenerator[*ast.BinaryExpr]{}
@@ -37,9 +38,13 @@ func addBinaryGenerators() {
 }
 
 func stringBinaryTypeGenerator(p *whereBodyParser, s *ast.BinaryExpr, args map[string]int) Addable {
-	return newBinary(
-		p.exprToAddable(s.X, args),
-		"||", // We can only concat strings, so all good.
-		p.exprToAddable(s.Y, args),
-	)
+	// Strings are compared as any other values.
+	if s.Op != token.ADD {
+		return nil
+	}
+
+	return concatenation{
+		dialect: p.dialect,
+		parts:   []Addable{p.exprToAddable(s.X, args), p.exprToAddable(s.Y, args)},
+	}
 }
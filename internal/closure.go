@@ -0,0 +1,810 @@
+package internal
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"go/version"
+	"slices"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// maxByValueSize is the size of the largest
+// variable the compiler captures by value.
+const maxByValueSize = 128
+
+// Closure is a layout of the closure of the lambda, values
+// of the local variables captured by it are taken from it
+// instead of being passed as arguments of the call.
+//
+// Closure of the function is a struct of the pointer to its
+// code followed by captured variables, in order they are first
+// used in the function. The compiler captures variables by value
+// unless their address is taken, they are larger than 128 bytes
+// or assigned after they are captured, in which case pointers to
+// them are captured. If it is not known how the variables are
+// captured, i.e. in generic functions, the closure is not used.
+type Closure struct {
+	// Fields are declarations of the fields of the
+	// closure that follow the pointer to the function.
+	Fields []string
+	// Values are expressions of the captured values used in the
+	// lambda, they are passed after the arguments of the call.
+	Values []string
+
+	vars      []capturedVar
+	argsCount int
+}
+
+type capturedVar struct {
+	obj   *types.Var
+	byRef bool
+	// named reports whether the type of the
+	// variable can be named in generated code.
+	named bool
+}
+
+// arg returns position of the value of the expression in the arguments
+// of the generated function, if it is taken from the closure.
+func (cl *Closure) arg(c *Context, expr ast.Expr) (int, bool) {
+	if cl == nil {
+		return 0, false
+	}
+
+	value, ok := cl.value(c, expr)
+	if !ok {
+		return 0, false
+	}
+
+	i := slices.Index(cl.Values, value)
+	if i == -1 {
+		i = len(cl.Values)
+		cl.Values = append(cl.Values, value)
+	}
+
+	return cl.argsCount + i, true
+}
+
+// value returns expression of the value in the generated capture
+// function, if it only uses captured variables, their fields,
+// elements and constants.
+func (cl *Closure) value(c *Context, expr ast.Expr) (string, bool) {
+	if tv := c.TypeInfo.Types[expr]; tv.Value != nil {
+		switch tv.Value.Kind() {
+		case constant.Bool, constant.String, constant.Int:
+			return tv.Value.ExactString(), true
+		default:
+			return "", false
+		}
+	}
+
+	if c.packageVar(expr) {
+		return c.exprName(expr), true
+	}
+
+	switch expr := expr.(type) {
+	case *ast.ParenExpr:
+		return cl.value(c, expr.X)
+	case *ast.Ident:
+		i := slices.IndexFunc(cl.vars, func(v capturedVar) bool {
+			return v.obj == c.TypeInfo.Uses[expr]
+		})
+		if i == -1 || !cl.vars[i].named {
+			return "", false
+		}
+
+		if cl.vars[i].byRef {
+			return fmt.Sprintf("(*closure.V%d)", i), true
+		}
+
+		return fmt.Sprintf("closure.V%d", i), true
+	case *ast.SelectorExpr:
+		if sel := c.TypeInfo.Selections[expr]; sel == nil || sel.Kind() != types.FieldVal {
+			return "", false
+		}
+
+		x, ok := cl.value(c, expr.X)
+
+		return x + "." + expr.Sel.Name, ok
+	case *ast.IndexExpr:
+		if !c.TypeInfo.Types[expr.X].IsValue() {
+			return "", false
+		}
+
+		x, ok := cl.value(c, expr.X)
+		if !ok {
+			return "", false
+		}
+
+		index, ok := cl.value(c, expr.Index)
+
+		return x + "[" + index + "]", ok
+	default:
+		return "", false
+	}
+}
+
+// closureOf returns layout of the closure of the lambda passed to
+// the call, or nil if the lambda does not capture local variables
+// or it is not known how they are captured.
+func (c *Context) closureOf(call *ast.CallExpr, lambda ast.Expr, argsCount int) *Closure {
+	if call.Ellipsis.IsValid() {
+		return nil
+	}
+
+	file := c.fileOf(lambda.Pos())
+	if file == nil {
+		return nil
+	}
+
+	lit, ok := c.closureLit(file, lambda)
+	if !ok {
+		return nil
+	}
+
+	vars := c.freeVars(lit)
+	if len(vars) == 0 {
+		return nil
+	}
+
+	path, _ := astutil.PathEnclosingInterval(file, lit.Pos(), lit.End())
+	if isGeneric(outermostFunc(path)) {
+		return nil
+	}
+
+	direct := lit == ast.Unparen(lambda)
+
+	closure := &Closure{argsCount: argsCount}
+	for i, v := range vars {
+		byRef, ok := c.capturedByRef(file, v, lit, direct)
+		if !ok {
+			return nil
+		}
+
+		name, named := c.typeName(lit, v.Type())
+		switch {
+		case byRef && named:
+			name = "*" + name
+		case byRef:
+			// Unused, but its pointer is a part of the closure.
+			name, named = "unsafe.Pointer", true
+			c.addImport(lit, "unsafe", "unsafe")
+		case !named:
+			return nil
+		}
+
+		closure.Fields = append(closure.Fields, fmt.Sprintf("V%d %s", i, name))
+		closure.vars = append(closure.vars, capturedVar{obj: v, byRef: byRef, named: named && name != "unsafe.Pointer"})
+	}
+
+	return closure
+}
+
+// fileOf returns file of the package the position is in.
+func (c *Context) fileOf(pos token.Pos) *ast.File {
+	files := c.Files
+	if files == nil {
+		files = []*ast.File{c.AstFile}
+	}
+
+	for _, file := range files {
+		if file.FileStart <= pos && pos <= file.FileEnd {
+			return file
+		}
+	}
+
+	return nil
+}
+
+// closureLit returns function literal the closure of the lambda is
+// created from. Lambda may be a local variable the literal is assigned
+// to, if it is not reassigned, while functions declared in the package
+// do not have closures.
+func (c *Context) closureLit(file *ast.File, lambda ast.Expr) (*ast.FuncLit, bool) {
+	switch lambda := ast.Unparen(lambda).(type) {
+	case *ast.FuncLit:
+		return lambda, true
+	case *ast.Ident:
+		v, ok := c.TypeInfo.Uses[lambda].(*types.Var)
+		if !ok {
+			return nil, false
+		}
+
+		assign, ok := c.declOf(v).(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != len(assign.Rhs) {
+			return nil, false
+		}
+
+		i := slices.IndexFunc(assign.Lhs, func(lhs ast.Expr) bool {
+			return c.TypeInfo.Defs[lhs.(*ast.Ident)] == v
+		})
+		lit, ok := ast.Unparen(assign.Rhs[i]).(*ast.FuncLit)
+		if !ok {
+			return nil, false
+		}
+
+		path, _ := astutil.PathEnclosingInterval(file, v.Pos(), v.Pos())
+		fn := declaringFunc(path)
+		if fn == nil {
+			return nil, false
+		}
+
+		uses := c.varUses(fn, v)
+		if len(uses.assigns) != 0 || uses.addrTaken || uses.unknown {
+			return nil, false
+		}
+
+		return lit, true
+	default:
+		return nil, false
+	}
+}
+
+// freeVars returns local variables used in the function literal
+// but declared outside of it, in order they are first used.
+func (c *Context) freeVars(lit *ast.FuncLit) []*types.Var {
+	var vars []*types.Var
+	ast.Inspect(lit.Body, func(node ast.Node) bool {
+		ident, ok := node.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		v, ok := c.TypeInfo.Uses[ident].(*types.Var)
+		if !ok || v.IsField() || v.Pkg() == nil || v.Parent() == v.Pkg().Scope() {
+			return true
+		}
+
+		if (v.Pos() < lit.Pos() || v.Pos() >= lit.End()) && !slices.Contains(vars, v) {
+			vars = append(vars, v)
+		}
+
+		return true
+	})
+
+	return vars
+}
+
+// isGeneric reports whether the function is generic or a method of
+// generic type, closures of such functions hold type dictionaries.
+func isGeneric(fn ast.Node) bool {
+	decl, ok := fn.(*ast.FuncDecl)
+	if !ok {
+		return false
+	}
+
+	if decl.Type.TypeParams != nil {
+		return true
+	}
+
+	if decl.Recv == nil {
+		return false
+	}
+
+	recv := decl.Recv.List[0].Type
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+
+	switch recv.(type) {
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		return true
+	default:
+		return false
+	}
+}
+
+// capturesKnown reports whether it is known how closures created in
+// the function capture its variables. Loops with labels and gotos,
+// as well as bodies of range-over-func loops, are rewritten by the
+// compiler, and variables used only in dead code are not captured.
+func (c *Context) capturesKnown(fn ast.Node) bool {
+	known := true
+	ast.Inspect(fn, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.LabeledStmt:
+			known = false
+		case *ast.BranchStmt:
+			known = known && node.Tok != token.GOTO
+		case *ast.RangeStmt:
+			_, isFunc := c.TypeInfo.TypeOf(node.X).Underlying().(*types.Signature)
+			known = known && !isFunc
+		case *ast.IfStmt:
+			known = known && !c.hasConstOperand(node.Cond)
+		case *ast.ForStmt:
+			known = known && (node.Cond == nil || !c.hasConstOperand(node.Cond))
+		case *ast.SwitchStmt:
+			if node.Tag != nil {
+				known = known && !c.hasConstOperand(node.Tag)
+				break
+			}
+
+			for _, clause := range node.Body.List {
+				for _, expr := range clause.(*ast.CaseClause).List {
+					known = known && !c.hasConstOperand(expr)
+				}
+			}
+		case *ast.CaseClause:
+			known = known && !hasUnreachable(node.Body)
+		case *ast.CommClause:
+			known = known && !hasUnreachable(node.Body)
+		case *ast.BlockStmt:
+			known = known && !hasUnreachable(node.List)
+		}
+
+		return known
+	})
+
+	return known
+}
+
+// hasConstOperand reports whether the condition is constant
+// or has a constant operand of logical operators, as
+// the compiler removes branches that are never taken.
+func (c *Context) hasConstOperand(cond ast.Expr) bool {
+	if c.TypeInfo.Types[cond].Value != nil {
+		return true
+	}
+
+	switch cond := cond.(type) {
+	case *ast.ParenExpr:
+		return c.hasConstOperand(cond.X)
+	case *ast.UnaryExpr:
+		return cond.Op == token.NOT && c.hasConstOperand(cond.X)
+	case *ast.BinaryExpr:
+		if cond.Op == token.LAND || cond.Op == token.LOR {
+			return c.hasConstOperand(cond.X) || c.hasConstOperand(cond.Y)
+		}
+	}
+
+	return false
+}
+
+// hasUnreachable reports whether statements follow
+// the one that may terminate the list of statements.
+func hasUnreachable(list []ast.Stmt) bool {
+	for _, stmt := range list[:max(len(list)-1, 0)] {
+		if mayTerminate(stmt) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mayTerminate reports whether the statement is terminating, as
+// defined by the spec, except that breaks out of it are ignored.
+func mayTerminate(stmt ast.Stmt) bool {
+	endsList := func(list []ast.Stmt) bool {
+		return len(list) != 0 && mayTerminate(list[len(list)-1])
+	}
+
+	clauses := func(body *ast.BlockStmt, needDefault bool) bool {
+		hasDefault := !needDefault
+		for _, clause := range body.List {
+			var list []ast.Stmt
+			switch clause := clause.(type) {
+			case *ast.CaseClause:
+				hasDefault = hasDefault || clause.List == nil
+				list = clause.Body
+			case *ast.CommClause:
+				list = clause.Body
+			}
+
+			if !endsList(list) {
+				return false
+			}
+		}
+
+		return hasDefault
+	}
+
+	switch stmt := stmt.(type) {
+	case *ast.ReturnStmt, *ast.BranchStmt:
+		return true
+	case *ast.ExprStmt:
+		call, ok := stmt.X.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+
+		ident, ok := ast.Unparen(call.Fun).(*ast.Ident)
+
+		return ok && ident.Name == "panic"
+	case *ast.BlockStmt:
+		return endsList(stmt.List)
+	case *ast.IfStmt:
+		return stmt.Else != nil && mayTerminate(stmt.Body) && mayTerminate(stmt.Else)
+	case *ast.ForStmt:
+		return stmt.Cond == nil
+	case *ast.SwitchStmt:
+		return clauses(stmt.Body, true)
+	case *ast.TypeSwitchStmt:
+		return clauses(stmt.Body, true)
+	case *ast.SelectStmt:
+		return clauses(stmt.Body, false)
+	default:
+		return false
+	}
+}
+
+// varUses are uses of the local variable in the function it is
+// declared in, which tell how closures capture the variable.
+type varUses struct {
+	assigns []varUse
+	// captures are function literals directly in
+	// the function that use the variable.
+	captures  []varUse
+	addrTaken bool
+	// unknown is set if it is not known
+	// whether the address of the variable is taken.
+	unknown bool
+}
+
+type varUse struct {
+	node ast.Node
+	// loops is the number of loops the use is in.
+	loops int
+	// header is set if the use is in a loop header.
+	header bool
+	// inLit is set if the use is in a function literal.
+	inLit bool
+}
+
+// capturedByRef reports whether the variable is captured by
+// reference by the function literal, ok is false if it is not
+// known. Direct is set if the literal is passed to the call.
+func (c *Context) capturedByRef(file *ast.File, v *types.Var, lit *ast.FuncLit, direct bool) (byRef, ok bool) {
+	path, _ := astutil.PathEnclosingInterval(file, v.Pos(), v.Pos())
+	fn := declaringFunc(path)
+	if fn == nil || !c.capturesKnown(fn) {
+		return false, false
+	}
+
+	// Named results are assigned by return statements.
+	if fields, ok := path[2].(*ast.FieldList); ok {
+		if funcType, ok := path[3].(*ast.FuncType); ok && funcType.Results == fields {
+			return false, false
+		}
+	}
+
+	uses := c.varUses(fn, v)
+	if uses.unknown || len(uses.captures) == 0 || uses.captures[0].header {
+		return false, false
+	}
+
+	// Sizes of the types differ between architectures.
+	var large []bool
+	for _, arch := range []string{"amd64", "386"} {
+		large = append(large, types.SizesFor("gc", arch).Sizeof(v.Type()) > maxByValueSize)
+	}
+
+	if large[0] != large[1] {
+		return false, false
+	}
+
+	// Loop variables are declared in the loop for each iteration since
+	// Go 1.22, they are assigned by the loop before its body is run.
+	loopVar := isLoopVar(path)
+	if loopVar && version.Compare(c.TypeInfo.FileVersions[file], "go1.22") < 0 {
+		return false, false
+	}
+
+	if uses.addrTaken || large[0] {
+		return true, true
+	}
+
+	assigns := slices.DeleteFunc(uses.assigns, func(assign varUse) bool {
+		loop, ok := path[2].(*ast.ForStmt)
+		return loopVar && ok && assign.node == loop.Post
+	})
+	if len(assigns) == 0 && !loopVar {
+		return false, true
+	}
+
+	// Closures that are called where they are created may be inlined,
+	// so they do not capture variables, and the first capture
+	// is only known if it is the lambda passed to the call.
+	first := uses.captures[0]
+	if !direct || first.node != lit {
+		return false, false
+	}
+
+	for _, assign := range assigns {
+		if assign.inLit || assign.header {
+			return false, false
+		}
+
+		if assign.node.Pos() > lit.Pos() {
+			return true, true
+		}
+	}
+
+	// Variables assigned before they are captured are captured
+	// by value, unless they are assigned in a loop which
+	// is not the one they are declared in.
+	declPath := slices.Clone(path[:slices.Index(path, fn)+1])
+	slices.Reverse(declPath)
+
+	loops := newVarUse(declPath).loops
+	if loopVar {
+		loops++
+	}
+
+	return loops != first.loops, true
+}
+
+// varUses returns uses of the variable in the function.
+func (c *Context) varUses(fn ast.Node, v *types.Var) varUses {
+	var uses varUses
+	var stack []ast.Node
+
+	assigned := func(expr ast.Expr) bool {
+		if ident, ok := expr.(*ast.Ident); ok && c.TypeInfo.Defs[ident] != nil {
+			return false
+		}
+
+		return c.rootVar(expr) == v
+	}
+
+	ast.Inspect(fn, func(node ast.Node) bool {
+		if node == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+
+		stack = append(stack, node)
+
+		switch node := node.(type) {
+		case *ast.Ident:
+			i := slices.IndexFunc(stack[1:], func(node ast.Node) bool {
+				_, ok := node.(*ast.FuncLit)
+				return ok
+			})
+			if i == -1 || c.TypeInfo.Uses[node] != v {
+				break
+			}
+
+			lit := stack[i+1]
+			if !slices.ContainsFunc(uses.captures, func(use varUse) bool { return use.node == lit }) {
+				uses.captures = append(uses.captures, newVarUse(stack[:i+2]))
+			}
+		case *ast.AssignStmt:
+			if slices.ContainsFunc(node.Lhs, assigned) {
+				uses.assigns = append(uses.assigns, newVarUse(stack))
+			}
+		case *ast.IncDecStmt:
+			if assigned(node.X) {
+				uses.assigns = append(uses.assigns, newVarUse(stack))
+			}
+		case *ast.RangeStmt:
+			if node.Tok == token.ASSIGN && slices.ContainsFunc([]ast.Expr{node.Key, node.Value}, func(expr ast.Expr) bool {
+				return expr != nil && assigned(expr)
+			}) {
+				use := newVarUse(stack)
+				use.header = true
+				uses.assigns = append(uses.assigns, use)
+			}
+		case *ast.UnaryExpr:
+			if node.Op == token.AND && c.rootVar(node.X) == v {
+				uses.addrTaken = true
+			}
+		case *ast.SliceExpr:
+			// Arrays are sliced by their address.
+			if _, ok := c.TypeInfo.TypeOf(node.X).Underlying().(*types.Array); ok && c.rootVar(node.X) == v {
+				uses.addrTaken = true
+			}
+		case *ast.SelectorExpr:
+			// Methods with pointer receivers take
+			// address of the addressable values.
+			sel := c.TypeInfo.Selections[node]
+			if sel == nil || sel.Kind() != types.MethodVal || c.rootVar(node.X) != v {
+				break
+			}
+
+			recv := sel.Obj().Type().(*types.Signature).Recv().Type()
+			_, ptrRecv := types.Unalias(recv).(*types.Pointer)
+			_, ptrX := c.TypeInfo.TypeOf(node.X).Underlying().(*types.Pointer)
+			switch {
+			case !ptrRecv || ptrX:
+			case len(sel.Index()) > 1:
+				// Promoted methods may be of embedded pointers.
+				uses.unknown = true
+			default:
+				uses.addrTaken = true
+			}
+		}
+
+		return true
+	})
+
+	return uses
+}
+
+// rootVar returns the variable which value is the expression
+// or contains it, as a field or an element of the array.
+func (c *Context) rootVar(expr ast.Expr) types.Object {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return c.TypeInfo.ObjectOf(expr)
+	case *ast.ParenExpr:
+		return c.rootVar(expr.X)
+	case *ast.SelectorExpr:
+		if sel := c.TypeInfo.Selections[expr]; sel == nil || sel.Kind() != types.FieldVal || sel.Indirect() {
+			return nil
+		}
+
+		return c.rootVar(expr.X)
+	case *ast.IndexExpr:
+		if _, ok := c.TypeInfo.TypeOf(expr.X).Underlying().(*types.Array); !ok {
+			return nil
+		}
+
+		return c.rootVar(expr.X)
+	default:
+		return nil
+	}
+}
+
+// newVarUse returns use of the variable by the last node
+// of the stack, which starts with the function.
+func newVarUse(stack []ast.Node) varUse {
+	use := varUse{node: stack[len(stack)-1]}
+	for i, node := range stack[1 : len(stack)-1] {
+		child := stack[i+2]
+
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			use.inLit = true
+		case *ast.ForStmt:
+			if child == node.Body {
+				use.loops++
+			} else {
+				use.header = true
+			}
+		case *ast.RangeStmt:
+			if child == node.Body {
+				use.loops++
+			} else {
+				use.header = true
+			}
+		}
+	}
+
+	return use
+}
+
+// isLoopVar reports whether the variable of the
+// path to its declaration is declared by a loop.
+func isLoopVar(path []ast.Node) bool {
+	switch parent := path[1].(type) {
+	case *ast.RangeStmt:
+		return parent.Tok == token.DEFINE
+	case *ast.AssignStmt:
+		loop, ok := path[2].(*ast.ForStmt)
+		return ok && loop.Init == parent
+	default:
+		return false
+	}
+}
+
+// declaringFunc returns the innermost function of the path.
+func declaringFunc(path []ast.Node) ast.Node {
+	for _, node := range path {
+		switch node.(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			return node
+		}
+	}
+
+	return nil
+}
+
+// outermostFunc returns the outermost function of the path.
+func outermostFunc(path []ast.Node) ast.Node {
+	for _, node := range slices.Backward(path) {
+		switch node.(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			return node
+		}
+	}
+
+	return nil
+}
+
+// typeName returns name of the type in the generated file, or
+// false if it cannot be named there, like types declared in
+// functions or unexported types of other packages.
+func (c *Context) typeName(node ast.Node, tp types.Type) (string, bool) {
+	if !c.nameable(tp) {
+		return "", false
+	}
+
+	var pkgs []*types.Package
+	name := types.TypeString(tp, func(pkg *types.Package) string {
+		if pkg.Name() == c.PackageName {
+			return ""
+		}
+
+		pkgs = append(pkgs, pkg)
+
+		return pkg.Name()
+	})
+
+	for _, pkg := range pkgs {
+		for path, name := range c.Imports {
+			if name == pkg.Name() && path != pkg.Path() {
+				return "", false
+			}
+		}
+	}
+
+	for _, pkg := range pkgs {
+		c.addImport(node, pkg.Path(), pkg.Name())
+	}
+
+	return name, true
+}
+
+func (c *Context) nameable(tp types.Type) bool {
+	nameable := func(obj *types.TypeName, args *types.TypeList) bool {
+		if pkg := obj.Pkg(); pkg != nil {
+			if obj.Parent() != pkg.Scope() || pkg.Name() != c.PackageName && !obj.Exported() {
+				return false
+			}
+		}
+
+		for i := range args.Len() {
+			if !c.nameable(args.At(i)) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	switch tp := tp.(type) {
+	case *types.Basic:
+		return tp.Kind() != types.UnsafePointer && tp.Info()&types.IsUntyped == 0
+	case *types.Named:
+		return nameable(tp.Obj(), tp.TypeArgs())
+	case *types.Alias:
+		return nameable(tp.Obj(), tp.TypeArgs())
+	case *types.Pointer:
+		return c.nameable(tp.Elem())
+	case *types.Slice:
+		return c.nameable(tp.Elem())
+	case *types.Array:
+		return c.nameable(tp.Elem())
+	case *types.Chan:
+		return c.nameable(tp.Elem())
+	case *types.Map:
+		return c.nameable(tp.Key()) && c.nameable(tp.Elem())
+	case *types.Signature:
+		for _, tuple := range []*types.Tuple{tp.Params(), tp.Results()} {
+			for i := range tuple.Len() {
+				if !c.nameable(tuple.At(i).Type()) {
+					return false
+				}
+			}
+		}
+
+		return true
+	case *types.Struct:
+		for i := range tp.NumFields() {
+			field := tp.Field(i)
+			if !field.Exported() && field.Pkg().Name() != c.PackageName || !c.nameable(field.Type()) {
+				return false
+			}
+		}
+
+		return true
+	case *types.Interface:
+		return tp.Empty()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,192 @@
+// Code generated by goquery; DO NOT EDIT.
+
+package internal_test
+
+import (
+	"github.com/ffenix113/goquery"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	goquery.RegisterPackagePath("github.com/ffenix113/goquery/internal")
+	goquery.AddToGlobalEntity[*Extensive](
+		goquery.Calls{
+			Where: map[goquery.Caller]goquery.QueryFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/closure_test.go", Line: 21}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? > ? AND ? = ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							args[0],
+							goquery.Column(helper, "StringCol"),
+							args[1]}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/closure_test.go", Line: 43}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ? AND ? = ? AND ? > ? OR ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							args[0],
+							goquery.Column(helper, "StringCol"),
+							args[1],
+							goquery.Column(helper, "IntCol"),
+							args[2],
+							args[3]}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/closure_test.go", Line: 57}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ? AND ? = ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							args[0],
+							goquery.Column(helper, "IntCol"),
+							args[1]}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/closure_test.go", Line: 65}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ? AND ? = ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							args[0],
+							goquery.Column(helper, "IntCol"),
+							args[1]}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/closure_test.go", Line: 74}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							args[0]}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/closure_test.go", Line: 84}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							args[0]}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/closure_test.go", Line: 94}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							args[0]}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/closure_test.go", Line: 103}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ? OR ? = ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							args[0],
+							goquery.Column(helper, "IntCol"),
+							args[1]}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/closure_test.go", Line: 112}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							args[0]}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/closure_test.go", Line: 122}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? < ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							args[0]}...)
+				},
+			},
+			Captures: map[string]map[goquery.Caller]goquery.CaptureFunc{
+				"Where": {
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/closure_test.go", Line: 21}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 int
+							V1 string
+						})(goquery.Closure(fn))
+
+						return []any{closure.V0, closure.V1}
+					},
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/closure_test.go", Line: 43}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 int
+							V1 string
+							V2 int8
+							V3 bool
+						})(goquery.Closure(fn))
+
+						return []any{closure.V0, closure.V1, closure.V2, closure.V3}
+					},
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/closure_test.go", Line: 57}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 *struct {
+								Name string
+								Pad  [128]byte
+							}
+							V1 int
+						})(goquery.Closure(fn))
+
+						return []any{(*closure.V0).Name, closure.V1}
+					},
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/closure_test.go", Line: 65}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 *Extensive
+						})(goquery.Closure(fn))
+
+						return []any{closure.V0.StringCol, closure.V0.IntCol}
+					},
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/closure_test.go", Line: 74}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 int
+						})(goquery.Closure(fn))
+
+						return []any{closure.V0}
+					},
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/closure_test.go", Line: 84}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 *int
+						})(goquery.Closure(fn))
+
+						return []any{(*closure.V0)}
+					},
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/closure_test.go", Line: 94}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 *counter
+						})(goquery.Closure(fn))
+
+						return []any{(*closure.V0).n}
+					},
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/closure_test.go", Line: 103}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 *[2]int
+						})(goquery.Closure(fn))
+
+						return []any{(*closure.V0)[0], (*closure.V0)[1]}
+					},
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/closure_test.go", Line: 112}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 string
+						})(goquery.Closure(fn))
+
+						return []any{closure.V0}
+					},
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/closure_test.go", Line: 122}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 int
+						})(goquery.Closure(fn))
+
+						return []any{closure.V0}
+					},
+				},
+			},
+		},
+	)
+}
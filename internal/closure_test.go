@@ -0,0 +1,144 @@
+//go:generate go run ../cmd/goquery/main.go
+
+package internal_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ffenix113/goquery"
+	"github.com/ffenix113/goquery/goquerytest"
+)
+
+type counter struct{ n int }
+
+func (c *counter) inc() { c.n++ }
+
+func byCaptured(q goquery.Queryable[*Extensive], name string, minInt int) {
+	q.Where(func(e *Extensive) bool { return e.IntCol > minInt && e.StringCol == name })
+}
+
+// Values of the captured variables are read from the closure
+// of the lambda, so wrong layout of it gives wrong values.
+func TestCapturedVariables(t *testing.T) {
+	tests := []struct {
+		name   string
+		f      func(q goquery.Queryable[*Extensive])
+		result string
+	}{
+		{
+			name: "parameters",
+			f: func(q goquery.Queryable[*Extensive]) {
+				byCaptured(q, "param", 3)
+			},
+			result: `("int_col" > 3 AND "string_col" = 'param')`,
+		},
+		{
+			name: "order of use",
+			f: func(q goquery.Queryable[*Extensive]) {
+				small, name, flag, n := int8(2), "name", true, 5
+				q.Where(func(e *Extensive) bool {
+					return e.IntCol == n && e.StringCol == name && e.IntCol > int(small) || flag
+				})
+			},
+			result: `("int_col" = 5 AND "string_col" = 'name' AND "int_col" > 2 OR TRUE)`,
+		},
+		{
+			name: "large struct",
+			f: func(q goquery.Queryable[*Extensive]) {
+				n := 1
+				large := struct {
+					Name string
+					Pad  [128]byte
+				}{Name: "large"}
+				q.Where(func(e *Extensive) bool { return e.StringCol == large.Name && e.IntCol == n })
+			},
+			result: `("string_col" = 'large' AND "int_col" = 1)`,
+		},
+		{
+			name: "pointer",
+			f: func(q goquery.Queryable[*Extensive]) {
+				row := &Extensive{StringCol: "row", IntCol: 3}
+				q.Where(func(e *Extensive) bool { return e.StringCol == row.StringCol && e.IntCol == row.IntCol })
+			},
+			result: `("string_col" = 'row' AND "int_col" = 3)`,
+		},
+		{
+			name: "assigned before capture",
+			f: func(q goquery.Queryable[*Extensive]) {
+				n := 1
+				n = 2
+				q.Where(func(e *Extensive) bool { return e.IntCol == n })
+			},
+			result: `("int_col" = 2)`,
+		},
+		{
+			name: "assigned in loop",
+			f: func(q goquery.Queryable[*Extensive]) {
+				n := 0
+				for _, v := range []int{1, 2} {
+					n = v
+					q.Where(func(e *Extensive) bool { return e.IntCol == n })
+				}
+			},
+			result: `("int_col" = 1) AND ("int_col" = 2)`,
+		},
+		{
+			name: "pointer method",
+			f: func(q goquery.Queryable[*Extensive]) {
+				var c counter
+				c.inc()
+				q.Where(func(e *Extensive) bool { return e.IntCol == c.n })
+			},
+			result: `("int_col" = 1)`,
+		},
+		{
+			name: "sliced array",
+			f: func(q goquery.Queryable[*Extensive]) {
+				ids := [2]int{1, 2}
+				copy(ids[:], []int{3})
+				q.Where(func(e *Extensive) bool { return e.IntCol == ids[0] || e.IntCol == ids[1] })
+			},
+			result: `("int_col" = 3 OR "int_col" = 2)`,
+		},
+		{
+			name: "nested closure",
+			f: func(q goquery.Queryable[*Extensive]) {
+				name := "nested"
+				func() {
+					q.Where(func(e *Extensive) bool { return e.StringCol == name })
+				}()
+			},
+			result: `("string_col" = 'nested')`,
+		},
+		{
+			name: "lambda variable",
+			f: func(q goquery.Queryable[*Extensive]) {
+				n := 4
+				filter := func(e *Extensive) bool { return e.IntCol < n }
+				q.Where(filter)
+			},
+			result: `("int_col" < 4)`,
+		},
+	}
+
+	for _, test := range tests {
+		db := getDB(t)
+
+		factory := goquery.NewFactory[*Extensive](db)
+
+		t.Run(test.name, func(t *testing.T) {
+			q := factory.New()
+			test.f(q)
+
+			var wrapper goquerytest.Conn
+			_, err := q.Query().Conn(&wrapper).Exec(context.Background())
+			require.NoError(t, err)
+
+			assert.Equal(t, test.result, wrapper.Query[len("select * where "):])
+		})
+	}
+}
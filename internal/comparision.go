@@ -1,112 +1,81 @@
 package internal
 
 import (
-	"bytes"
 	"fmt"
 	"go/ast"
-	"go/printer"
 	"go/token"
+	"go/types"
 	"math/bits"
 	"strconv"
 	"strings"
+
+	"github.com/ffenix113/goquery/internal/sqlexpr"
 )
 
-const param = "?"
+const param = sqlexpr.Param
 
-type comparisonsAnd []Addable
+type (
+	comparisonsAnd = sqlexpr.And
+	comparisonOr   = sqlexpr.Or
+	binary         = sqlexpr.Binary
+)
 
 func newComparisonAnd(comparisons ...Addable) comparisonsAnd {
-	return comparisons
+	return sqlexpr.NewAnd(comparisons...)
 }
 
-func (c comparisonsAnd) String() string {
-	var buf strings.Builder
-
-	for _, comparison := range c {
-		if buf.Len() > 0 {
-			buf.WriteString(" AND ")
-		}
-		buf.WriteString(comparison.String())
-	}
-
-	return buf.String()
+func newComparisonOr(left, right Addable) comparisonOr {
+	return sqlexpr.NewOr(left, right)
 }
 
-func (c comparisonsAnd) Args() []any {
-	var args []any
-
-	for _, comparison := range c {
-		args = append(args, comparison.Args()...)
+func newComparison(parser *whereBodyParser, binaryExpr *ast.BinaryExpr) Addable {
+	if _, ok := operations[binaryExpr.Op]; !ok {
+		parser.c.panicWithPosf(binaryExpr, "operator %s is not supported", binaryExpr.Op)
 	}
 
-	return args
-}
-
-type comparisonOr struct {
-	left, right Addable
-}
-
-func newComparisonOr(left, right Addable) comparisonOr {
-	return comparisonOr{left, right}
-}
-
-func (c comparisonOr) String() string {
-	return fmt.Sprintf("%s OR %s", c.left.String(), c.right.String())
-}
+	left := parser.exprToAddable(binaryExpr.X, parser.args)
+	right := parser.exprToAddable(binaryExpr.Y, parser.args)
 
-func (c comparisonOr) Args() []any {
-	return append(c.left.Args(), c.right.Args()...)
-}
+	// Multiplied intervals stay intervals,
+	// so they could be rendered for the dialect.
+	if binaryExpr.Op == token.MUL {
+		if interval, ok := right.(interval); ok {
+			return interval.times(left)
+		}
 
-type binary struct {
-	Op    string
-	Left  Addable
-	Right Addable
-}
+		if interval, ok := left.(interval); ok {
+			return interval.times(right)
+		}
+	}
 
-func newComparison(parser *whereBodyParser, binaryExpr *ast.BinaryExpr) Addable {
-	return newBinary(
-		parser.exprToAddable(binaryExpr.X, parser.args),
-		tokenToOperation(binaryExpr.Op),
-		parser.exprToAddable(binaryExpr.Y, parser.args),
-	)
+	return newBinary(left, tokenToOperation(binaryExpr.Op), right)
 }
 
 func newBinary(left Addable, op string, right Addable) Addable {
-	cmp := &binary{
-		Left:  left,
-		Right: right,
-		Op:    op,
-	}
+	return sqlexpr.NewBinary(left, op, right)
+}
 
-	return cmp
+// operations are SQL operators of Go operators.
+var operations = map[token.Token]string{
+	token.EQL: "=",
+	token.GTR: ">",
+	token.LSS: "<",
+	token.NEQ: "!=",
+	token.LEQ: "<=",
+	token.GEQ: ">=",
+	token.SUB: "-",
+	token.ADD: "+",
+	token.MUL: "*",
+	token.QUO: "/",
 }
 
 func tokenToOperation(cmpToken token.Token) string {
-	switch cmpToken {
-	case token.EQL:
-		return "="
-	case token.GTR:
-		return ">"
-	case token.LSS:
-		return "<"
-	case token.NEQ:
-		return "!="
-	case token.LEQ:
-		return "<="
-	case token.GEQ:
-		return ">="
-	case token.SUB:
-		return "-"
-	case token.ADD:
-		return "+"
-	case token.MUL:
-		return "*"
-	case token.QUO:
-		return "/"
-	default:
+	op, ok := operations[cmpToken]
+	if !ok {
 		panic("unsupported operator: " + cmpToken.String())
 	}
+
+	return op
 }
 
 func (p *whereBodyParser) fromBinaryExpr(expr *ast.BinaryExpr, args map[string]int) Addable {
@@ -124,9 +93,17 @@ func (p *whereBodyParser) exprToAddable(s ast.Expr, args map[string]int) Addable
 	return p.getAddable(s, args)
 }
 
+// exprName returns name of the identifier or selector
+// to use it in generated code. Packages of the selectors
+// are imported with the same names as in the source,
+// as their names may differ from the import paths.
 func (c *Context) exprName(expr ast.Expr) string {
 	switch expr := expr.(type) {
 	case *ast.Ident:
+		if pkgName, ok := c.TypeInfo.Uses[expr].(*types.PkgName); ok {
+			c.addImport(expr, pkgName.Imported().Path(), pkgName.Name())
+		}
+
 		return expr.Name
 	case *ast.SelectorExpr:
 		return c.exprName(expr.X) + "." + expr.Sel.Name
@@ -136,52 +113,190 @@ func (c *Context) exprName(expr ast.Expr) string {
 	}
 }
 
-func getArg(val *ast.BasicLit) (arg any) {
-	switch val.Kind {
-	case token.INT:
-		arg, _ = strconv.ParseInt(val.Value, 10, bits.UintSize)
-	case token.FLOAT:
-		arg, _ = strconv.ParseFloat(val.Value, bits.UintSize)
-	case token.STRING:
-		arg, _ = strconv.Unquote(val.Value)
-	default:
-		panic(fmt.Sprintf("unsupported type: %s", val.Kind))
-	}
+// isPackageName reports whether the identifier is a name of the imported package.
+func (c *Context) isPackageName(ident *ast.Ident) bool {
+	_, ok := c.TypeInfo.Uses[ident].(*types.PkgName)
 
-	return arg
+	return ok
 }
 
-func (c *binary) String() string {
-	var buf strings.Builder
+// addImport adds import of the package to the generated file.
+func (c *Context) addImport(node ast.Node, path, name string) {
+	if c.Imports == nil {
+		c.Imports = map[string]string{}
+	}
 
-	buf.WriteString(c.Left.String())
-	buf.WriteByte(' ')
-	buf.WriteString(c.Op)
-	buf.WriteByte(' ')
-	buf.WriteString(c.Right.String())
+	for importedPath, importedName := range c.Imports {
+		if importedName == name && importedPath != path {
+			c.panicWithPosf(node, "packages %s and %s are imported with the same name %s", importedPath, path, name)
+		}
+	}
 
-	return buf.String()
+	c.Imports[path] = name
 }
 
-func (c *binary) Args() []any {
-	return append(append([]any(nil), c.Left.Args()...), c.Right.Args()...)
+// argName returns name of the argument expression.
+//
+// Expressions of the arguments are matched to the ones in
+// lambda by AST equivalence: names of variables are qualified
+// with position of their declaration, so arguments are matched
+// to the variables used in lambda, and not to other variables
+// with the same name, and parentheses are ignored.
+func (c *Context) argName(expr ast.Expr) string {
+	name, ok := c.exprKey(expr)
+	if !ok {
+		c.panicWithPosf(expr, "unsupported expression type %T to get name from", expr)
+	}
+
+	return name
 }
 
-func fromArgs(pos int) raw {
-	return raw(fmt.Sprintf("args[%d]", pos))
+// exprKey returns key of the expression that is the
+// same for equivalent expressions, see argName.
+func (c *Context) exprKey(expr ast.Expr) (string, bool) {
+	keys := func(exprs ...ast.Expr) (string, bool) {
+		res := make([]string, 0, len(exprs))
+		for _, expr := range exprs {
+			key, ok := "", true
+			if expr != nil {
+				key, ok = c.exprKey(expr)
+			}
+
+			if !ok {
+				return "", false
+			}
+
+			res = append(res, key)
+		}
+
+		return strings.Join(res, ", "), true
+	}
+
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		if obj := c.TypeInfo.ObjectOf(expr); obj != nil {
+			return expr.Name + "@" + strconv.Itoa(int(obj.Pos())), true
+		}
+
+		return expr.Name, true
+	case *ast.BasicLit:
+		return expr.Value, true
+	case *ast.ParenExpr:
+		return c.exprKey(expr.X)
+	case *ast.SelectorExpr:
+		key, ok := c.exprKey(expr.X)
+
+		return key + "." + expr.Sel.Name, ok
+	case *ast.StarExpr:
+		key, ok := c.exprKey(expr.X)
+
+		return "*" + key, ok
+	case *ast.UnaryExpr:
+		key, ok := c.exprKey(expr.X)
+
+		return expr.Op.String() + key, ok
+	case *ast.BinaryExpr:
+		left, leftOK := c.exprKey(expr.X)
+		right, rightOK := c.exprKey(expr.Y)
+
+		return "(" + left + " " + expr.Op.String() + " " + right + ")", leftOK && rightOK
+	case *ast.IndexExpr:
+		key, ok := keys(expr.X, expr.Index)
+
+		return "index(" + key + ")", ok
+	case *ast.IndexListExpr:
+		key, ok := keys(append([]ast.Expr{expr.X}, expr.Indices...)...)
+
+		return "index(" + key + ")", ok
+	case *ast.SliceExpr:
+		key, ok := keys(expr.X, expr.Low, expr.High, expr.Max)
+
+		return "slice(" + key + ")", ok
+	case *ast.CallExpr:
+		// Conversions are calls of the type.
+		key, ok := keys(append([]ast.Expr{expr.Fun}, expr.Args...)...)
+		if expr.Ellipsis.IsValid() {
+			key += "..."
+		}
+
+		return "call(" + key + ")", ok
+	case *ast.CompositeLit:
+		key, ok := keys(append([]ast.Expr{expr.Type}, expr.Elts...)...)
+
+		return "lit(" + key + ")", ok
+	case *ast.KeyValueExpr:
+		key, ok := keys(expr.Key, expr.Value)
+
+		return "kv(" + key + ")", ok
+	case *ast.ArrayType, *ast.MapType, *ast.ChanType, *ast.FuncType, *ast.InterfaceType, *ast.StructType:
+		// Types are not evaluated, so they
+		// are equivalent if they are the same.
+		if typ := c.TypeInfo.TypeOf(expr); typ != nil {
+			return typ.String(), true
+		}
+
+		return types.ExprString(expr), true
+	default:
+		return "", false
+	}
 }
 
-func (c *Context) panicWithPosf(node ast.Node, msg string, args ...any) {
-	formattedMsg := fmt.Sprintf(msg, args...)
-	if _, ok := node.(ast.Expr); ok {
-		var b bytes.Buffer
+// packageVar reports whether the expression is a package-level
+// variable or a field of it. Generated code is in the same
+// package, so it could use them without passing as arguments.
+func (c *Context) packageVar(expr ast.Expr) bool {
+	root := expr
+	for {
+		selector, ok := root.(*ast.SelectorExpr)
+		if !ok {
+			break
+		}
 
-		if err := printer.Fprint(&b, c.FileSet, node); err != nil {
-			fmt.Println(err)
+		if ident, ok := selector.X.(*ast.Ident); ok {
+			if _, ok := c.TypeInfo.Uses[ident].(*types.PkgName); ok {
+				// Variable of another package, it will
+				// be imported on the template execution.
+				return isPackageVar(c.TypeInfo.Uses[selector.Sel])
+			}
 		}
 
-		panic(fmt.Sprintf("%s (expr: `%s`): %s", c.FileSet.Position(node.Pos()).String(), b.String(), formattedMsg))
+		root = selector.X
+	}
+
+	ident, ok := root.(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	// Dot-imported variables of other
+	// packages are not qualified in the source.
+	obj := c.TypeInfo.Uses[ident]
+
+	return isPackageVar(obj) && obj.Pkg().Name() == c.PackageName
+}
+
+func isPackageVar(obj types.Object) bool {
+	v, ok := obj.(*types.Var)
+
+	return ok && v.Pkg() != nil && v.Parent() == v.Pkg().Scope()
+}
+
+// literalArg returns value of the literal.
+func (p *whereBodyParser) literalArg(val *ast.BasicLit) (arg any) {
+	switch val.Kind {
+	case token.INT:
+		arg, _ = strconv.ParseInt(val.Value, 10, bits.UintSize)
+	case token.FLOAT:
+		arg, _ = strconv.ParseFloat(val.Value, bits.UintSize)
+	case token.STRING:
+		arg, _ = strconv.Unquote(val.Value)
+	default:
+		p.c.panicWithPosf(val, "literal of kind %s is not supported", val.Kind)
 	}
 
-	panic(fmt.Sprintf("%s: %s", c.FileSet.Position(node.Pos()).String(), formattedMsg))
+	return arg
+}
+
+func fromArgs(pos int) raw {
+	return raw(fmt.Sprintf("args[%d]", pos))
 }
@@ -3,6 +3,7 @@ package internal
 import (
 	"go/ast"
 	"go/types"
+	"slices"
 )
 
 func addConstGenerators() {
@@ -13,26 +14,30 @@ func addConstGenerators() {
 	//	const val = 55
 	//	const val = math.MaxInt
 	addGenerator(func(p *whereBodyParser, s *ast.Ident, args map[string]int) Addable {
-		if s.Obj == nil || s.Obj.Kind != ast.Con {
-			objVal := p.c.TypeInfo.ObjectOf(s)
-			if _, ok := objVal.(*types.Const); ok {
-				// If constant is just an Ident - it means that
-				// it is defined in the same package
-				return NewSimple(param, raw(p.c.exprName(s)))
-			}
-
+		constObj, ok := p.c.TypeInfo.Uses[s].(*types.Const)
+		if !ok {
 			return nil
 		}
 
-		switch constVal := s.Obj.Decl.(*ast.ValueSpec).Values[0].(type) {
-		case *ast.BasicLit:
-			// Just use a direct value for now.
-			// TODO: support package constants by name, not value.
-			return NewSimple(param, getArg(constVal))
-		case *ast.SelectorExpr:
-			// If it is a selector - use its name.
-			// Import for it will be added on template execution.
-			return NewSimple(param, raw(p.c.exprName(constVal)))
+		if valSpec, ok := p.c.declOf(constObj).(*ast.ValueSpec); ok && len(valSpec.Values) == len(valSpec.Names) {
+			i := slices.IndexFunc(valSpec.Names, func(name *ast.Ident) bool { return name.Pos() == constObj.Pos() })
+
+			switch constVal := valSpec.Values[i].(type) {
+			case *ast.BasicLit:
+				// Just use a direct value for now.
+				// TODO: support package constants by name, not value.
+				return NewSimple(param, p.literalArg(constVal))
+			case *ast.SelectorExpr:
+				// If it is a selector - use its name,
+				// its package is imported by exprName.
+				return NewSimple(param, raw(p.c.exprName(constVal)))
+			}
+		}
+
+		// Constants declared in the package, or predeclared
+		// ones, can be used by name in the generated code.
+		if constObj.Pkg() == nil || constObj.Parent() == constObj.Pkg().Scope() && constObj.Pkg().Name() == p.c.PackageName {
+			return NewSimple(param, raw(p.c.exprName(s)))
 		}
 
 		return nil
@@ -50,8 +55,7 @@ func addConstGenerators() {
 
 		if _, ok := obj.(*types.Const); ok {
 			// Same as in the above const evaluation - if it is a const
-			// from another package - it will be imported on the
-			// template execution.
+			// from another package - it is imported by exprName.
 			return NewSimple(param, raw(p.c.exprName(s)))
 		}
 
@@ -3,31 +3,188 @@ package internal
 import (
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/token"
 	"go/types"
+	"maps"
+	"path"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strconv"
+	"strings"
 
+	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/go/packages"
 )
 
 const ProjectName = "goquery"
 const InterfaceName = "Queryable"
+const GroupName = "Group"
+
+// Calls field names which will hold generated functions.
+const (
+	CallsWhere   = "Where"
+	CallsOrderBy = "OrderBy"
+	CallsSelect  = "Select"
+	CallsGroupBy = "GroupBy"
+	CallsInclude = "Include"
+	CallsUpdate  = "Update"
+)
+
+// queryableMethod describes Queryable method that accepts lambda.
+type queryableMethod struct {
+	// callsField is the Calls field generated
+	// function will be placed in.
+	callsField string
+	// funcArg is the position of lambda in method arguments.
+	// Arguments for the lambda follow right after it.
+	funcArg int
+	// negate is set if filter should select rows
+	// that do not satisfy the lambda.
+	negate bool
+	// nested describes a lambda that follows the first
+	// one and accepts entities returned by it.
+	nested nestedLambda
+}
+
+type nestedLambda int
+
+const (
+	noNested nestedLambda = iota
+	// nestedFilter filters entities of the relation.
+	nestedFilter
+	// nestedRelation selects relation of the relation.
+	nestedRelation
+)
+
+// queryableMethods maps Queryable methods
+// that accept lambdas to their description.
+var queryableMethods = map[string]queryableMethod{
+	"Where":             {callsField: CallsWhere},
+	"OrderBy":           {callsField: CallsOrderBy},
+	"OrderByDescending": {callsField: CallsOrderBy},
+	"ThenBy":            {callsField: CallsOrderBy},
+	"ThenByDescending":  {callsField: CallsOrderBy},
+	// All checks that there are no rows that do not satisfy the lambda.
+	"All":     {callsField: CallsWhere, funcArg: 1, negate: true},
+	"Include": {callsField: CallsInclude},
+	"Update":  {callsField: CallsUpdate, funcArg: 1},
+}
+
+// queryableFuncs maps goquery package functions
+// that accept Queryable as the first argument
+// and lambda after it to their description.
+var queryableFuncs = map[string]queryableMethod{
+	"Select":       {callsField: CallsSelect, funcArg: 1},
+	"GroupBy":      {callsField: CallsGroupBy, funcArg: 1},
+	"IncludeWhere": {callsField: CallsInclude, funcArg: 1, nested: nestedFilter},
+	"ThenInclude":  {callsField: CallsInclude, funcArg: 1, nested: nestedRelation},
+}
 
 type Context struct {
 	FileSet *token.FileSet
 	AstFile *ast.File
 
 	PackageName string
+	// PackagePath is a module-relative path of the package
+	// directory, i.e. `github.com/ffenix113/goquery/internal`.
+	// Callers are keyed with it instead of absolute paths.
+	PackagePath string
 
-	Data map[string]map[token.Position]QueryData // EntityName(type Arg) -> caller -> query
+	Data map[string]EntityCalls // EntityName(type Arg) -> calls
+	// Imports are packages of the variables and constants
+	// used by generated code, by their paths. Their names
+	// are the same as in the source.
+	Imports map[string]string
 
 	TypeInfo *types.Info
+	// Files are all files of the package, functions
+	// declared in them are inlined into queries.
+	Files []*ast.File
+	// Packages are loaded packages by their types. Functions
+	// of other packages of the module are inlined if syntax of
+	// their package is loaded, its type info is added to TypeInfo.
+	Packages map[*types.Package]*packages.Package
+
+	// Report is called with diagnostics of the calls that
+	// cannot be generated. If it is set, generation continues
+	// with other calls, otherwise it panics with the diagnostic.
+	Report func(Diagnostic)
+}
+
+// EntityCalls holds generated queries of a single entity.
+type EntityCalls map[string]map[CallSite]QueryData // Calls field -> caller -> query
+
+// Captures returns closures of the lambdas of the calls
+// that values of the captured variables are taken from.
+func (e EntityCalls) Captures() map[string]map[CallSite]*Closure {
+	var captures map[string]map[CallSite]*Closure
+	for field, calls := range e {
+		for site, data := range calls {
+			if data.Closure == nil || len(data.Closure.Values) == 0 {
+				continue
+			}
+
+			if captures == nil {
+				captures = map[string]map[CallSite]*Closure{}
+			}
+
+			if captures[field] == nil {
+				captures[field] = map[CallSite]*Closure{}
+			}
+
+			captures[field][site] = data.Closure
+		}
+	}
+
+	return captures
+}
+
+// CallSite is a position of the call in the source. Column is
+// not available at runtime, so calls of the same kind on the
+// same line are told apart by the order they are evaluated in.
+type CallSite struct {
+	Filename string
+	Line     int
+	// Index of the call among calls on the line.
+	Index int
 }
 
 type QueryData struct {
-	Query string
-	Args  []string
+	// Clause is a method of the query that
+	// filter is added with, i.e. Where or Having.
+	Clause string
+	Query  string
+	Args   []string
+	// Relation is a name of the included relation.
+	Relation string
+	// DialectQueries and DialectArgs hold variants of the
+	// query for dialects, if they differ from the default.
+	DialectQueries map[string]string
+	DialectArgs    map[string][]string
+	// Closure is a layout of the closure of the lambda,
+	// if values of its captured variables are used.
+	Closure *Closure
+}
+
+// addDialect adds variant of the query for the dialect.
+func (d *QueryData) addDialect(dialect string, variant QueryData) {
+	if variant.Query != d.Query {
+		if d.DialectQueries == nil {
+			d.DialectQueries = make(map[string]string)
+		}
+
+		d.DialectQueries[dialect] = variant.Query
+	}
+
+	if !slices.Equal(variant.Args, d.Args) {
+		if d.DialectArgs == nil {
+			d.DialectArgs = make(map[string][]string)
+		}
+
+		d.DialectArgs[dialect] = variant.Args
+	}
 }
 
 func newQueryData(addable Addable) QueryData {
@@ -46,95 +203,319 @@ func newQueryData(addable Addable) QueryData {
 	}
 
 	return QueryData{
-		Query: addable.String(),
-		Args:  strArgs,
+		Clause: "Where",
+		Query:  addable.String(),
+		Args:   strArgs,
 	}
 }
 
-func (c *Context) ParseFile(filePath string) error {
+func (c *Context) ParseFile(filePath string) (err error) {
 	c.FileSet = token.NewFileSet()
-	c.AstFile, c.TypeInfo = c.getTypeInfo(filePath, c.FileSet)
+	c.AstFile, c.TypeInfo, err = c.getTypeInfo(filePath, c.FileSet)
 
 	// _ = ast.Print(FileSet, AstFile)
 
-	return nil
+	return err
 }
 
-func (c *Context) getTypeInfo(filePath string, fileSet *token.FileSet) (astFile *ast.File, typesInfo *types.Info) {
-	pkgs, err := packages.Load(&packages.Config{
-		Tests: true,
-		Fset:  fileSet,
-		Mode:  packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
-	}, filepath.Dir(filePath))
+func (c *Context) getTypeInfo(filePath string, fileSet *token.FileSet) (astFile *ast.File, typesInfo *types.Info, err error) {
+	pkgs, err := loadPackages(fileSet, filepath.Dir(filePath))
 	if err != nil {
-		panic(err)
+		return nil, nil, err
 	}
 
 	for _, pkg := range pkgs {
-		for i, fileName := range pkg.GoFiles {
+		for i, fileName := range pkg.CompiledGoFiles {
 			if fileName == filePath {
-				return pkg.Syntax[i], pkg.TypesInfo
+				c.PackagePath = packagePath(pkg, filePath)
+				c.Files = pkg.Syntax
+				c.Packages = packagesByTypes(pkgs)
+
+				return pkg.Syntax[i], pkg.TypesInfo, nil
 			}
 		}
 	}
 
-	panic("file not found in parsed packages")
+	return nil, nil, fmt.Errorf("file %s not found in parsed packages", filePath)
 }
 
 func (c *Context) Visit(node ast.Node) (w ast.Visitor) {
+	defer func() {
+		if r := recover(); r != nil {
+			if !c.recoverDiagnostic(r) {
+				panic(r)
+			}
+
+			// Calls nested in the failed one
+			// are still checked.
+			w = c
+		}
+	}()
+
 	switch n := node.(type) {
 	case *ast.File:
 		c.PackageName = n.Name.Name
 	case *ast.CallExpr:
-		selector, ok := n.Fun.(*ast.SelectorExpr)
+		method, queryableExpr, name, ok := c.queryableCall(n)
 		if !ok {
 			break
 		}
 
-		if selector.Sel.Name != "Where" {
-			break
-		}
+		identType := c.TypeInfo.TypeOf(queryableExpr)
 
-		identType := c.TypeInfo.TypeOf(selector.X)
+		lambda := c.unwrapArgFunc(n.Args[method.funcArg])
 
-		identTypeObj := identType.(*types.Named).Obj()
-		if identTypeObj.Pkg().Name() != ProjectName || identTypeObj.Name() != InterfaceName {
-			break
+		argsStart := method.funcArg + 1
+		if method.nested != noNested {
+			argsStart++
 		}
 
-		whereFunc := c.unwrapArgFunc(n.Args[0])
+		// Variables are captured by the lambda which body
+		// is parsed, the relation lambda only selects fields.
+		closureArg := n.Args[method.funcArg]
+		if method.nested == nestedFilter {
+			closureArg = n.Args[method.funcArg+1]
+		}
 
-		paramName := whereFunc.Type.Params.List[0].Names[0].Name
+		paramName := lambda.Type.Params.List[0].Names[0].Name
 		bodyParser := whereBodyParser{
 			c:         c,
 			paramName: paramName,
-			args:      c.getArgNames(n.Args[1:]...),
+			args:      c.getArgNames(n.Args[argsStart:]...),
+			closure:   c.closureOf(n, closureArg, len(n.Args)-argsStart),
+			helper:    "helper",
+			query:     "query",
 		}
 		// Get type
-		typeName := getTypeArgName(identType)
+		typeName, grouped := c.entityName(name, identType)
 
-		addable := bodyParser.parse(whereFunc.Body)
-		typeCalls, ok := c.Data[typeName]
-		if !ok {
-			typeCalls = make(map[token.Position]QueryData)
-			c.Data[typeName] = typeCalls
+		// Lambda is rendered for each dialect,
+		// the first one is used as the default.
+		var queryData QueryData
+		for i, dialect := range dialects {
+			bodyParser.dialect = dialect
+
+			dialectData := c.parseQueryData(n, method, bodyParser, lambda)
+			if grouped {
+				dialectData.Clause = "Having"
+			}
+
+			if i == 0 {
+				queryData = dialectData
+				continue
+			}
+
+			queryData.addDialect(dialect, dialectData)
 		}
 
-		typeCalls[c.FileSet.Position(selector.Sel.Pos())] = newQueryData(addable)
+		queryData.Closure = bodyParser.closure
+
+		c.addQueryData(name, typeName, method.callsField, c.callSite(method.callsField, queryableExpr, name), queryData)
 	}
 	return c
 }
 
-func getTypeArgName(identType types.Type) string {
+// parseQueryData parses lambda of the call.
+func (c *Context) parseQueryData(call *ast.CallExpr, method queryableMethod, bodyParser whereBodyParser, lambda *ast.FuncLit) QueryData {
+	var addable Addable
+	switch method.callsField {
+	case CallsInclude:
+		return c.parseInclude(call, method, bodyParser, lambda)
+	case CallsWhere:
+		addable = bodyParser.parse(lambda.Body)
+	case CallsOrderBy, CallsGroupBy:
+		bodyParser.query = "query.QueryBuilder()"
+		addable = bodyParser.parseValue(lambda.Body)
+	case CallsSelect:
+		bodyParser.query = "query.QueryBuilder()"
+		addable = bodyParser.parseProjection(lambda.Body)
+	case CallsUpdate:
+		addable = bodyParser.parseAssignments(lambda.Body)
+	}
+
+	if method.negate {
+		addable = NotTrue{Addable: addable, dialect: bodyParser.dialect}
+	}
+
+	return newQueryData(addable)
+}
+
+// queryableCall checks if call is either a Queryable method
+// or goquery package function that accepts a lambda.
+//
+// It returns description of the called function, expression
+// of the Queryable it is called on and name of the function.
+func (c *Context) queryableCall(call *ast.CallExpr) (method queryableMethod, queryableExpr ast.Expr, name *ast.Ident, ok bool) {
+	fun := call.Fun
+	// Generic functions may be called with explicit type arguments.
+	switch typed := fun.(type) {
+	case *ast.IndexExpr:
+		fun = typed.X
+	case *ast.IndexListExpr:
+		fun = typed.X
+	}
+
+	selector, ok := fun.(*ast.SelectorExpr)
+	if !ok {
+		return method, nil, nil, false
+	}
+
+	if ident, ok := selector.X.(*ast.Ident); ok {
+		if pkgName, ok := c.TypeInfo.Uses[ident].(*types.PkgName); ok {
+			if pkgName.Imported().Name() != ProjectName {
+				return method, nil, nil, false
+			}
+
+			method, ok = queryableFuncs[selector.Sel.Name]
+
+			return method, call.Args[0], selector.Sel, ok
+		}
+	}
+
+	method, ok = queryableMethods[selector.Sel.Name]
+	if !ok {
+		return method, nil, nil, false
+	}
+
+	identType, ok := c.TypeInfo.TypeOf(selector.X).(*types.Named)
+	if !ok {
+		return method, nil, nil, false
+	}
+
+	identTypeObj := identType.Obj()
+	if identTypeObj.Pkg().Name() != ProjectName || identTypeObj.Name() != InterfaceName {
+		return method, nil, nil, false
+	}
+
+	return method, selector.X, selector.Sel, true
+}
+
+// parseInclude returns included relation with the
+// filter of its entities, if the call has one.
+func (c *Context) parseInclude(call *ast.CallExpr, method queryableMethod, bodyParser whereBodyParser, lambda *ast.FuncLit) QueryData {
+	relation := bodyParser.parseRelationPath(lambda.Body)
+	if method.nested == noNested {
+		return QueryData{Relation: relation}
+	}
+
+	nested := c.unwrapArgFunc(call.Args[method.funcArg+1])
+	nestedParser := bodyParser
+	nestedParser.paramName = nested.Type.Params.List[0].Names[0].Name
+
+	if method.nested == nestedRelation {
+		return QueryData{Relation: relation + "." + nestedParser.parseRelationPath(nested.Body)}
+	}
+
+	queryData := newQueryData(nestedParser.parse(nested.Body))
+	queryData.Relation = relation
+
+	return queryData
+}
+
+// callSite returns call site of the queryable call.
+//
+// Calls on the same line are only evaluated in known order
+// if they are chained, so index of the call is the number
+// of calls of the same kind on the same entity in the
+// queryable expression.
+func (c *Context) callSite(callsField string, queryableExpr ast.Expr, name *ast.Ident) CallSite {
+	pos := c.FileSet.Position(name.Pos())
+	site := CallSite{Filename: pos.Filename, Line: pos.Line}
+	entity, _ := getTypeArgName(c.TypeInfo.TypeOf(queryableExpr))
+
+	ast.Inspect(queryableExpr, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		method, callExpr, name, ok := c.queryableCall(call)
+		if !ok || method.callsField != callsField || c.FileSet.Position(name.Pos()).Line != site.Line {
+			return true
+		}
+
+		// Calls are numbered per entity, as each one has its own calls.
+		if callEntity, _ := getTypeArgName(c.TypeInfo.TypeOf(callExpr)); callEntity == entity {
+			site.Index++
+		}
+
+		return true
+	})
+
+	return site
+}
+
+func (c *Context) addQueryData(name *ast.Ident, typeName, callsField string, site CallSite, data QueryData) {
+	entityCalls, ok := c.Data[typeName]
+	if !ok {
+		entityCalls = make(EntityCalls)
+		c.Data[typeName] = entityCalls
+	}
+
+	fieldCalls, ok := entityCalls[callsField]
+	if !ok {
+		fieldCalls = make(map[CallSite]QueryData)
+		entityCalls[callsField] = fieldCalls
+	}
+
+	key := site
+	if c.PackagePath != "" {
+		key.Filename = path.Join(c.PackagePath, filepath.Base(site.Filename))
+	}
+
+	if _, ok := fieldCalls[key]; ok {
+		c.panicWithPosf(name, "%s calls on the same line cannot be told apart, chain them or put them on separate lines", callsField)
+	}
+
+	fieldCalls[key] = data
+}
+
+// getTypeArgName returns name of the entity Queryable is defined for.
+//
+// For Queryable of Group the entity is the type
+// of grouped rows, in which case grouped is set.
+func getTypeArgName(identType types.Type) (name string, grouped bool) {
+	entity, grouped := getEntityType(identType)
+
+	return entity.Obj().Name(), grouped
+}
+
+// entityName returns name of the entity Queryable is defined for,
+// qualified with its package if it is declared in another package.
+func (c *Context) entityName(name *ast.Ident, identType types.Type) (string, bool) {
+	entity, grouped := getEntityType(identType)
+
+	pkg := entity.Obj().Pkg()
+	if pkg == nil || pkg.Name() == c.PackageName {
+		return entity.Obj().Name(), grouped
+	}
+
+	c.addImport(name, pkg.Path(), pkg.Name())
+
+	return pkg.Name() + "." + entity.Obj().Name(), grouped
+}
+
+func getEntityType(identType types.Type) (entity *types.Named, grouped bool) {
 	argType := identType.(*types.Named).TypeArgs().At(0)
 
+	if named, ok := argType.(*types.Named); ok && isGroupType(named) {
+		argType = named.TypeArgs().At(1)
+		grouped = true
+	}
+
 	ptr, isPtr := argType.(*types.Pointer)
 	for isPtr {
 		argType = ptr.Elem()
 		ptr, isPtr = argType.(*types.Pointer)
 	}
 
-	return argType.(*types.Named).Obj().Name()
+	return argType.(*types.Named), grouped
+}
+
+func isGroupType(named *types.Named) bool {
+	obj := named.Obj()
+
+	return obj.Pkg() != nil && obj.Pkg().Name() == ProjectName && obj.Name() == GroupName
 }
 
 func (c *Context) getArgNames(exprs ...ast.Expr) map[string]int {
@@ -144,7 +525,7 @@ func (c *Context) getArgNames(exprs ...ast.Expr) map[string]int {
 
 	names := make(map[string]int, len(exprs))
 	for i, expr := range exprs {
-		names[c.exprName(expr)] = i
+		names[c.argName(expr)] = i
 	}
 
 	return names
@@ -153,7 +534,7 @@ func (c *Context) getArgNames(exprs ...ast.Expr) map[string]int {
 func (c *Context) unwrapArgFunc(expr ast.Expr) *ast.FuncLit {
 	switch argType := expr.(type) {
 	case *ast.Ident:
-		switch declType := argType.Obj.Decl.(type) {
+		switch declType := c.declOf(c.TypeInfo.Uses[argType]).(type) {
 		case *ast.FuncDecl:
 			return &ast.FuncLit{
 				Type: declType.Type,
@@ -174,13 +555,464 @@ func (c *Context) unwrapArgFunc(expr ast.Expr) *ast.FuncLit {
 	return nil
 }
 
+// declOf returns the node declaring the object, like *ast.FuncDecl,
+// *ast.ValueSpec, *ast.AssignStmt or *ast.Field, if it is declared
+// in one of the package files or in the loaded imported package.
+//
+// Declarations are found by position of the object rather than
+// with ast.Ident.Obj, which is not set if files are parsed with
+// parser.SkipObjectResolution, as gopls and analyzers do.
+func (c *Context) declOf(obj types.Object) ast.Node {
+	if obj == nil || !obj.Pos().IsValid() {
+		return nil
+	}
+
+	files := c.Files
+	if files == nil {
+		files = []*ast.File{c.AstFile}
+	}
+
+	if name, decl := declAt(files, obj.Pos()); decl != nil {
+		return decl
+	} else if name != nil {
+		return nil
+	}
+
+	// Only packages of the module are inlined from,
+	// as the others, i.e. of the standard library,
+	// are implemented with unsupported expressions.
+	pkg, ok := c.Packages[obj.Pkg()]
+	if !ok || pkg.Module == nil || !pkg.Module.Main {
+		return nil
+	}
+
+	name, decl := declAt(pkg.Syntax, obj.Pos())
+	if decl != nil && c.TypeInfo.Defs[name] == nil {
+		// Declaration is parsed with the type
+		// info of the package it is declared in.
+		c.addTypeInfo(pkg.TypesInfo)
+	}
+
+	return decl
+}
+
+// declAt returns name declared at the position
+// and the declaration it is a part of.
+func declAt(files []*ast.File, pos token.Pos) (*ast.Ident, ast.Node) {
+	for _, file := range files {
+		if file == nil || pos < file.Pos() || pos > file.End() {
+			continue
+		}
+
+		path, _ := astutil.PathEnclosingInterval(file, pos, pos)
+		if len(path) < 2 {
+			return nil, nil
+		}
+
+		// Object is declared by the parent of its name.
+		if ident, ok := path[0].(*ast.Ident); ok && ident.Pos() == pos {
+			return ident, path[1]
+		}
+
+		return nil, nil
+	}
+
+	return nil, nil
+}
+
+// addTypeInfo adds type info of another package to TypeInfo.
+// Keys of the maps are nodes of the package syntax, so
+// they do not conflict with the ones of this package.
+func (c *Context) addTypeInfo(info *types.Info) {
+	maps.Copy(c.TypeInfo.Types, info.Types)
+	maps.Copy(c.TypeInfo.Defs, info.Defs)
+	maps.Copy(c.TypeInfo.Uses, info.Uses)
+	maps.Copy(c.TypeInfo.Implicits, info.Implicits)
+	maps.Copy(c.TypeInfo.Selections, info.Selections)
+	maps.Copy(c.TypeInfo.Instances, info.Instances)
+	maps.Copy(c.TypeInfo.Scopes, info.Scopes)
+}
+
 func (p *whereBodyParser) parse(body *ast.BlockStmt) Addable {
-	returnStmt, ok := body.List[0].(*ast.ReturnStmt)
+	p.locals = map[types.Object]ast.Expr{}
+
+	res := p.foldStmts(body.List)
+	if res.isConst {
+		return NewSimple(param, res.value)
+	}
+
+	return res.addable
+}
+
+// foldedFilter is a filter folded from statements.
+type foldedFilter struct {
+	addable Addable
+	// isConst is set if statements return constant
+	// true or false, which is set as value.
+	isConst, value bool
+}
+
+// foldStmts folds statements of the filter body into single filter.
+//
+// Local variables are inlined where they are used, and
+// branches of if statements are joined with AND and OR,
+// so `if a { return b }; return c` becomes `a AND b OR NOT a AND c`.
+func (p *whereBodyParser) foldStmts(stmts []ast.Stmt) foldedFilter {
+	for i, stmt := range stmts {
+		switch stmt := stmt.(type) {
+		case *ast.ReturnStmt:
+			return p.foldReturn(stmt.Results[0])
+		case *ast.AssignStmt:
+			p.bindLocals(stmt)
+		case *ast.DeclStmt:
+			p.bindVars(stmt)
+		case *ast.IfStmt:
+			return p.foldIf(stmt, stmts[i+1:])
+		case *ast.SwitchStmt:
+			return p.foldFilterBranches(p.foldBranches(stmts[i:]))
+		case *ast.EmptyStmt:
+		default:
+			p.c.panicWithPosf(stmt, "filter function can only have local variables, if and switch statements and returns")
+		}
+	}
+
+	p.c.panicWithPosf(stmts[len(stmts)-1], "filter function must end with return statement")
+
+	return foldedFilter{}
+}
+
+func (p *whereBodyParser) foldReturn(expr ast.Expr) foldedFilter {
+	if ident, ok := ast.Unparen(expr).(*ast.Ident); ok && p.c.TypeInfo.Uses[ident] != nil {
+		if value := p.c.TypeInfo.Types[ident].Value; value != nil && value.Kind() == constant.Bool {
+			return foldedFilter{isConst: true, value: constant.BoolVal(value)}
+		}
+	}
+
+	return foldedFilter{addable: p.parseCondition(expr)}
+}
+
+// foldIf folds if statement with the statements that follow it,
+// which are executed if the branch of the statement does not return.
+func (p *whereBodyParser) foldIf(stmt *ast.IfStmt, rest []ast.Stmt) foldedFilter {
+	if stmt.Init != nil {
+		p.bindInit(stmt.Init)
+	}
+
+	cond := p.parseCondition(stmt.Cond)
+
+	then := p.foldStmts(append(slices.Clip(stmt.Body.List), rest...))
+
+	var elseStmts []ast.Stmt
+	switch elseStmt := stmt.Else.(type) {
+	case *ast.BlockStmt:
+		elseStmts = elseStmt.List
+	case *ast.IfStmt:
+		elseStmts = []ast.Stmt{elseStmt}
+	}
+
+	otherwise := p.foldStmts(append(slices.Clip(elseStmts), rest...))
+
+	return foldCond(cond, then, otherwise)
+}
+
+// foldCond folds filters selected by the condition into single filter.
+func foldCond(cond Addable, then, otherwise foldedFilter) foldedFilter {
+	switch {
+	case then.isConst && otherwise.isConst && then.value == otherwise.value:
+		return then
+	case then.isConst && otherwise.isConst && then.value:
+		return foldedFilter{addable: cond}
+	case then.isConst && otherwise.isConst:
+		return foldedFilter{addable: Not{cond}}
+	case then.isConst && then.value:
+		return foldedFilter{addable: newComparisonOr(Parens{Addable: cond}, Parens{Addable: otherwise.addable})}
+	case then.isConst:
+		return foldedFilter{addable: newComparisonAnd(Not{cond}, Parens{Addable: otherwise.addable})}
+	case otherwise.isConst && otherwise.value:
+		return foldedFilter{addable: newComparisonOr(Not{cond}, Parens{Addable: then.addable})}
+	case otherwise.isConst:
+		return foldedFilter{addable: newComparisonAnd(Parens{Addable: cond}, Parens{Addable: then.addable})}
+	default:
+		return foldedFilter{addable: newComparisonOr(
+			newComparisonAnd(Parens{Addable: cond}, Parens{Addable: then.addable}),
+			newComparisonAnd(Not{cond}, Parens{Addable: otherwise.addable}),
+		)}
+	}
+}
+
+// foldFilterBranches folds branches of switch statement into
+// single filter, as foldIf does with if statements. CASE is not
+// used, as MSSQL does not allow conditions as its values.
+func (p *whereBodyParser) foldFilterBranches(branches *valueBranches) foldedFilter {
+	if branches.expr != nil {
+		return p.foldReturn(branches.expr)
+	}
+
+	res := p.foldFilterBranches(branches.otherwise)
+	for i := len(branches.whens) - 1; i >= 0; i-- {
+		res = foldCond(branches.whens[i].cond, p.foldFilterBranches(branches.whens[i].then), res)
+	}
+
+	return res
+}
+
+// bindLocals binds local variables defined
+// with := to the expressions assigned to them.
+func (p *whereBodyParser) bindLocals(stmt *ast.AssignStmt) {
+	if stmt.Tok != token.DEFINE {
+		p.c.panicWithPosf(stmt, "local variables cannot be reassigned in filter function")
+	}
+
+	if len(stmt.Lhs) != len(stmt.Rhs) {
+		p.c.panicWithPosf(stmt, "each local variable must have single value")
+	}
+
+	for i, lhs := range stmt.Lhs {
+		p.bindLocal(lhs.(*ast.Ident), stmt.Rhs[i])
+	}
+}
+
+// bindVars binds local variables defined with var.
+func (p *whereBodyParser) bindVars(stmt *ast.DeclStmt) {
+	decl := stmt.Decl.(*ast.GenDecl)
+	if decl.Tok != token.VAR {
+		return
+	}
+
+	for _, spec := range decl.Specs {
+		valueSpec := spec.(*ast.ValueSpec)
+		if len(valueSpec.Names) != len(valueSpec.Values) {
+			p.c.panicWithPosf(valueSpec, "each local variable must have single value")
+		}
+
+		for i, name := range valueSpec.Names {
+			p.bindLocal(name, valueSpec.Values[i])
+		}
+	}
+}
+
+func (p *whereBodyParser) bindLocal(name *ast.Ident, value ast.Expr) {
+	if name.Name == "_" {
+		return
+	}
+
+	p.locals[p.c.TypeInfo.Defs[name]] = value
+}
+
+// local returns expression bound to the local variable
+// of this or one of the outer lambdas, which is used
+// instead of the variable.
+func (p *whereBodyParser) local(ident *ast.Ident) (ast.Expr, bool) {
+	obj := p.c.TypeInfo.Uses[ident]
+	if obj == nil {
+		return nil, false
+	}
+
+	for scope := p; scope != nil; scope = scope.parent {
+		if expr, ok := scope.locals[obj]; ok {
+			return expr, true
+		}
+	}
+
+	return nil, false
+}
+
+// value returns Addable bound to the parameter of the
+// function inlined in this or one of the outer lambdas.
+func (p *whereBodyParser) value(ident *ast.Ident) (Addable, bool) {
+	obj := p.c.TypeInfo.Uses[ident]
+	if obj == nil {
+		return nil, false
+	}
+
+	for scope := p; scope != nil; scope = scope.parent {
+		if value, ok := scope.values[obj]; ok {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+// valueBranches are values returned from the statements.
+//
+// It is either a single returned expression, or
+// conditions with values returned if they are satisfied
+// and values returned if none of them is.
+type valueBranches struct {
+	expr      ast.Expr
+	whens     []valueWhen
+	otherwise *valueBranches
+}
+
+type valueWhen struct {
+	cond Addable
+	then *valueBranches
+}
+
+// leaves calls f for each of the returned expressions.
+func (b *valueBranches) leaves(f func(expr ast.Expr)) {
+	if b.expr != nil {
+		f(b.expr)
+		return
+	}
+
+	for _, when := range b.whens {
+		when.then.leaves(f)
+	}
+
+	b.otherwise.leaves(f)
+}
+
+// foldBranches folds statements that return a value
+// depending on conditions of if and switch statements.
+func (p *whereBodyParser) foldBranches(stmts []ast.Stmt) *valueBranches {
+	for i, stmt := range stmts {
+		switch stmt := stmt.(type) {
+		case *ast.ReturnStmt:
+			return &valueBranches{expr: stmt.Results[0]}
+		case *ast.AssignStmt:
+			p.bindLocals(stmt)
+		case *ast.DeclStmt:
+			p.bindVars(stmt)
+		case *ast.IfStmt:
+			return p.foldIfBranches(stmt, stmts[i+1:])
+		case *ast.SwitchStmt:
+			return p.foldSwitchBranches(stmt, stmts[i+1:])
+		case *ast.EmptyStmt:
+		default:
+			p.c.panicWithPosf(stmt, "function can only have local variables, if and switch statements and returns")
+		}
+	}
+
+	p.c.panicWithPosf(stmts[len(stmts)-1], "function must end with return statement")
+
+	return nil
+}
+
+func (p *whereBodyParser) foldIfBranches(stmt *ast.IfStmt, rest []ast.Stmt) *valueBranches {
+	if stmt.Init != nil {
+		p.bindInit(stmt.Init)
+	}
+
+	var elseStmts []ast.Stmt
+	switch elseStmt := stmt.Else.(type) {
+	case *ast.BlockStmt:
+		elseStmts = elseStmt.List
+	case *ast.IfStmt:
+		elseStmts = []ast.Stmt{elseStmt}
+	}
+
+	return &valueBranches{
+		whens: []valueWhen{{
+			cond: p.parseCondition(stmt.Cond),
+			then: p.foldBranches(append(slices.Clip(stmt.Body.List), rest...)),
+		}},
+		otherwise: p.foldBranches(append(slices.Clip(elseStmts), rest...)),
+	}
+}
+
+// foldSwitchBranches folds switch statement, either with
+// a tag compared to values of the cases, or with conditions.
+func (p *whereBodyParser) foldSwitchBranches(stmt *ast.SwitchStmt, rest []ast.Stmt) *valueBranches {
+	if stmt.Init != nil {
+		p.bindInit(stmt.Init)
+	}
+
+	var tag Addable
+	if stmt.Tag != nil {
+		tag = p.getAddable(stmt.Tag, p.args)
+	}
+
+	branches := &valueBranches{}
+	// Statements after the switch are executed
+	// if there is no default case.
+	defaultStmts := rest
+
+	for _, clause := range stmt.Body.List {
+		clause := clause.(*ast.CaseClause)
+
+		body := slices.Clip(clause.Body)
+		if len(body) != 0 {
+			if branch, ok := body[len(body)-1].(*ast.BranchStmt); ok && branch.Tok == token.FALLTHROUGH {
+				p.c.panicWithPosf(branch, "fallthrough is not supported")
+			}
+		}
+
+		if clause.List == nil {
+			defaultStmts = append(body, rest...)
+			continue
+		}
+
+		conds := make([]Addable, 0, len(clause.List))
+		for _, expr := range clause.List {
+			if tag == nil {
+				conds = append(conds, p.parseCondition(expr))
+				continue
+			}
+
+			conds = append(conds, newBinary(tag, tokenToOperation(token.EQL), p.getAddable(expr, p.args)))
+		}
+
+		cond := conds[0]
+		if len(conds) > 1 {
+			cond = Parens{Addable: conds[0]}
+			for _, next := range conds[1:] {
+				cond = newComparisonOr(cond, Parens{Addable: next})
+			}
+		}
+
+		branches.whens = append(branches.whens, valueWhen{
+			cond: cond,
+			then: p.foldBranches(append(body, rest...)),
+		})
+	}
+
+	branches.otherwise = p.foldBranches(defaultStmts)
+	if len(branches.whens) == 0 {
+		return branches.otherwise
+	}
+
+	return branches
+}
+
+// bindInit binds local variables defined
+// in if or switch statement.
+func (p *whereBodyParser) bindInit(stmt ast.Stmt) {
+	assign, ok := stmt.(*ast.AssignStmt)
 	if !ok {
-		p.c.panicWithPosf(body.List[0], "filter function is expected to only have single return statement")
+		p.c.panicWithPosf(stmt, "only local variables can be defined in if and switch statements")
+	}
+
+	p.bindLocals(assign)
+}
+
+// caseOf returns CASE of the branches, or the value
+// if there are no conditions. Values of returned
+// expressions are created with value function.
+func (p *whereBodyParser) caseOf(branches *valueBranches, value func(expr ast.Expr) Addable) Addable {
+	if branches.expr != nil {
+		return value(branches.expr)
+	}
+
+	res := Case{Else: p.caseOf(branches.otherwise, value)}
+	for _, when := range branches.whens {
+		res.Whens = append(res.Whens, When{Cond: when.cond, Then: p.caseOf(when.then, value)})
 	}
 
-	switch tpd := returnStmt.Results[0].(type) {
+	// Else of the chained conditions is
+	// merged with them, as with `else if`.
+	if elseCase, ok := res.Else.(Case); ok {
+		res.Whens = append(res.Whens, elseCase.Whens...)
+		res.Else = elseCase.Else
+	}
+
+	return res
+}
+
+// parseCondition parses expression that is used as a filter.
+func (p *whereBodyParser) parseCondition(expr ast.Expr) Addable {
+	switch tpd := expr.(type) {
 	case *ast.BinaryExpr:
 		return p.parseBinaryExpression(tpd)
 	case *ast.SelectorExpr:
@@ -189,16 +1021,459 @@ func (p *whereBodyParser) parse(body *ast.BlockStmt) Addable {
 		return p.parseUnaryExpression(tpd)
 	case *ast.CallExpr:
 		return p.parseCallExpression(tpd)
-	default:
-		p.c.panicWithPosf(returnStmt.Results[0], "expression with type %T cannot be used as filter currently", tpd)
-		return nil
+	case *ast.ParenExpr:
+		return Parens{Addable: p.parseCondition(tpd.X)}
+	case *ast.Ident:
+		if value, ok := p.value(tpd); ok {
+			return value
+		}
+
+		if local, ok := p.local(tpd); ok {
+			return p.parseCondition(local)
+		}
+	}
+
+	p.c.panicWithPosf(expr, "expression with type %T cannot be used as filter currently", expr)
+
+	return nil
+}
+
+// parseAssignments parses body of a function that assigns
+// values to the fields of its parameter. Each assignment
+// becomes a column that is set to the assigned value.
+func (p *whereBodyParser) parseAssignments(body *ast.BlockStmt) Addable {
+	assignments := make(List, 0, len(body.List))
+	p.assigned = make(map[string]bool, len(body.List))
+
+	for _, stmt := range body.List {
+		switch stmt := stmt.(type) {
+		case *ast.AssignStmt:
+			if len(stmt.Lhs) != len(stmt.Rhs) {
+				p.c.panicWithPosf(stmt, "each assignment must have single value")
+			}
+
+			// All values are parsed before the fields are
+			// assigned, as Go evaluates them before assignment.
+			values := make([]Addable, 0, len(stmt.Rhs))
+			for _, rhs := range stmt.Rhs {
+				values = append(values, p.getAddable(rhs, p.args))
+			}
+
+			for i, lhs := range stmt.Lhs {
+				column := p.assignedColumn(lhs)
+
+				value := values[i]
+				switch stmt.Tok {
+				case token.ASSIGN:
+				case token.ADD_ASSIGN:
+					value = newBinary(column, tokenToOperation(token.ADD), Parens{Addable: value})
+				case token.SUB_ASSIGN:
+					value = newBinary(column, tokenToOperation(token.SUB), Parens{Addable: value})
+				case token.MUL_ASSIGN:
+					value = newBinary(column, tokenToOperation(token.MUL), Parens{Addable: value})
+				case token.QUO_ASSIGN:
+					value = newBinary(column, tokenToOperation(token.QUO), Parens{Addable: value})
+				default:
+					p.c.panicWithPosf(stmt, "unsupported assignment: %s", stmt.Tok)
+				}
+
+				assignments = append(assignments, newBinary(column, "=", value))
+			}
+		case *ast.IncDecStmt:
+			column := p.assignedColumn(stmt.X)
+
+			op := token.ADD
+			if stmt.Tok == token.DEC {
+				op = token.SUB
+			}
+
+			assignments = append(assignments, newBinary(column, "=", newBinary(column, tokenToOperation(op), NewSimple("1"))))
+		default:
+			p.c.panicWithPosf(stmt, "update function is expected to only have assignments to fields of the parameter")
+		}
+	}
+
+	if len(assignments) == 0 {
+		p.c.panicWithPosf(body, "update function must assign at least one field")
+	}
+
+	return assignments
+}
+
+// assignedColumn returns column of the parameter field
+// that is assigned a value in update function.
+//
+// Field can only be assigned once, as all values
+// are computed from the row before the update.
+func (p *whereBodyParser) assignedColumn(expr ast.Expr) Addable {
+	selector, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		p.c.panicWithPosf(expr, "only fields of the parameter can be assigned")
+	}
+
+	if scope, ok := p.scopeOf(selector); !ok || scope != p || len(p.relationHops(selector)) != 0 {
+		p.c.panicWithPosf(expr, "only fields of the parameter can be assigned")
+	}
+
+	if p.assigned[selector.Sel.Name] {
+		p.c.panicWithPosf(expr, "field %s is assigned more than once", selector.Sel.Name)
+	}
+
+	column, _ := p.column(selector)
+	p.assigned[selector.Sel.Name] = true
+
+	return column
+}
+
+// parseValue parses body of a function that returns
+// a value, like ordering key, instead of a filter condition.
+func (p *whereBodyParser) parseValue(body *ast.BlockStmt) Addable {
+	p.locals = map[types.Object]ast.Expr{}
+
+	return p.caseOf(p.foldBranches(body.List), func(expr ast.Expr) Addable {
+		return p.getAddable(expr, p.args)
+	})
+}
+
+// parseProjection parses body of a function that returns
+// a struct literal, each field of which becomes a column
+// named after the field of the result struct.
+//
+// If the function returns different struct literals depending
+// on conditions, each of them must set the same fields, and
+// columns become CASE of the values set in each of them.
+func (p *whereBodyParser) parseProjection(body *ast.BlockStmt) Addable {
+	p.locals = map[types.Object]ast.Expr{}
+
+	branches := p.foldBranches(body.List)
+
+	var fields []string
+	branches.leaves(func(expr ast.Expr) {
+		names := p.projectionFields(expr)
+		switch {
+		case fields == nil:
+			fields = names
+		case !slices.Equal(slices.Sorted(slices.Values(fields)), slices.Sorted(slices.Values(names))):
+			p.c.panicWithPosf(expr, "all returned struct literals must set the same fields")
+		}
+	})
+
+	columns := make(List, 0, len(fields))
+	for _, field := range fields {
+		value := p.caseOf(branches, func(expr ast.Expr) Addable {
+			return p.getAddable(p.projectionField(expr, field), p.args)
+		})
+
+		columns = append(columns, newColumnAlias(value, field))
 	}
+
+	return columns
+}
+
+// projectionLiteral returns struct literal returned from projection.
+func (p *whereBodyParser) projectionLiteral(result ast.Expr) (*ast.CompositeLit, *types.Struct) {
+	if unary, ok := result.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		result = unary.X
+	}
+
+	lit, ok := result.(*ast.CompositeLit)
+	if !ok {
+		p.c.panicWithPosf(result, "projection must return struct literal")
+	}
+
+	structType, ok := p.c.TypeInfo.TypeOf(lit).Underlying().(*types.Struct)
+	if !ok {
+		p.c.panicWithPosf(lit, "projection must return struct literal")
+	}
+
+	return lit, structType
+}
+
+// projectionFields returns names of the fields set in returned struct literal.
+func (p *whereBodyParser) projectionFields(result ast.Expr) []string {
+	lit, structType := p.projectionLiteral(result)
+
+	fields := make([]string, 0, len(lit.Elts))
+	for i, elt := range lit.Elts {
+		fieldName := structType.Field(i).Name()
+		if keyValue, ok := elt.(*ast.KeyValueExpr); ok {
+			fieldName = keyValue.Key.(*ast.Ident).Name
+		}
+
+		fields = append(fields, fieldName)
+	}
+
+	return fields
+}
+
+// projectionField returns value of the field set in returned struct literal.
+func (p *whereBodyParser) projectionField(result ast.Expr, field string) ast.Expr {
+	lit, structType := p.projectionLiteral(result)
+
+	for i, elt := range lit.Elts {
+		if keyValue, ok := elt.(*ast.KeyValueExpr); ok {
+			if keyValue.Key.(*ast.Ident).Name == field {
+				return keyValue.Value
+			}
+
+			continue
+		}
+
+		if structType.Field(i).Name() == field {
+			return elt
+		}
+	}
+
+	p.c.panicWithPosf(lit, "field %s is not set", field)
+
+	return nil
+}
+
+// parseLambda parses lambda passed as an argument,
+// for example to aggregate function, as a value.
+func (p *whereBodyParser) parseLambda(expr ast.Expr, args map[string]int) Addable {
+	lambda := p.c.unwrapArgFunc(expr)
+
+	lambdaParser := whereBodyParser{
+		c:         p.c,
+		paramName: lambda.Type.Params.List[0].Names[0].Name,
+		args:      args,
+		closure:   p.closure,
+		helper:    p.helper,
+		query:     p.query,
+		dialect:   p.dialect,
+	}
+
+	return lambdaParser.parseValue(lambda.Body)
+}
+
+// parseRelationFilter parses filter lambda for the
+// related entities of the relation field selected
+// from one of the parameters in scope.
+//
+// It returns expression of goquery.RelationInfo
+// and parsed filter.
+func (p *whereBodyParser) parseRelationFilter(relationExpr ast.Expr, filterExpr ast.Expr, args map[string]int) (string, Addable) {
+	relationField, ok := relationExpr.(*ast.SelectorExpr)
+	if !ok {
+		p.c.panicWithPosf(relationExpr, "relation must be a field of the parameter")
+	}
+
+	base, ok := p.scopeOf(relationField)
+	if !ok {
+		p.c.panicWithPosf(relationExpr, "relation must be a field of the parameter")
+	}
+
+	relation := "goquery.Relation(" + base.helper + ", \"" + relationField.Sel.Name + "\")"
+
+	lambda := p.c.unwrapArgFunc(filterExpr)
+	filterParser := whereBodyParser{
+		c:         p.c,
+		paramName: lambda.Type.Params.List[0].Names[0].Name,
+		args:      args,
+		closure:   p.closure,
+		helper:    relation + ".Helper",
+		query:     p.query,
+		dialect:   p.dialect,
+		parent:    p,
+	}
+
+	return relation, filterParser.parse(lambda.Body)
 }
 
 type whereBodyParser struct {
 	c         *Context
 	paramName string
 	args      map[string]int
+	// closure is a layout of the closure of the lambda,
+	// values of variables not passed as arguments are
+	// taken from it. It is shared with nested parsers.
+	closure *Closure
+	// helper is an expression of goquery.Helper
+	// that resolves columns of the parameter.
+	helper string
+	// query is an expression of bun.QueryBuilder
+	// that relations are joined to.
+	query string
+	// dialect is a name of the dialect
+	// SQL is rendered for.
+	dialect string
+	// parent is a parser of the lambda this one is nested in.
+	// It is set for subqueries, so columns of the outer
+	// lambda parameters could be used in them.
+	parent *whereBodyParser
+	// locals are expressions bound to local variables
+	// of the lambda, which are inlined where used.
+	locals map[types.Object]ast.Expr
+	// values are Addables of the arguments bound to
+	// parameters of inlined functions, which are
+	// converted before the function is inlined.
+	values map[types.Object]Addable
+	// inlining are functions which bodies are
+	// being inlined, to detect recursive calls.
+	inlining []*types.Func
+	// assigned are fields of the parameter that
+	// are already assigned by the update function.
+	assigned map[string]bool
+}
+
+// scopeOf returns parser of the lambda whose
+// parameter the field is selected from.
+func (p *whereBodyParser) scopeOf(expr *ast.SelectorExpr) (*whereBodyParser, bool) {
+	root := p.selectorBase(expr.X)
+	for {
+		selector, ok := root.(*ast.SelectorExpr)
+		if !ok {
+			break
+		}
+
+		root = p.selectorBase(selector.X)
+	}
+
+	ident, ok := root.(*ast.Ident)
+	if !ok {
+		return nil, false
+	}
+
+	for scope := p; scope != nil; scope = scope.parent {
+		if scope.paramName == ident.Name {
+			return scope, true
+		}
+	}
+
+	return nil, false
+}
+
+// selectorBase returns expression fields are selected from.
+//
+// Local variables are replaced with the bound expressions,
+// so fields of local variables and parameters of inlined
+// functions are selected from the parameters of the lambda.
+func (p *whereBodyParser) selectorBase(expr ast.Expr) ast.Expr {
+	if ident, ok := expr.(*ast.Ident); ok {
+		if local, ok := p.local(ident); ok {
+			return p.selectorBase(ast.Unparen(local))
+		}
+	}
+
+	return expr
+}
+
+// column returns column of the field if it is
+// selected from one of the parameters in scope.
+func (p *whereBodyParser) column(expr *ast.SelectorExpr) (*Simple, bool) {
+	scope, ok := p.scopeOf(expr)
+	if !ok {
+		return nil, false
+	}
+
+	helper := scope.helper
+	if scope != p && scope.parent == nil {
+		// Columns of the outer query must be qualified
+		// in subqueries, as subquery tables may
+		// have columns with the same names.
+		helper = "goquery.Qualified(" + helper + ")"
+	}
+
+	hops := p.relationHops(expr)
+	if len(hops) == 0 && scope.assigned[expr.Sel.Name] {
+		// SQL uses values of the row before the update,
+		// while in Go the assigned value would be read.
+		p.c.panicWithPosf(expr, "field %s cannot be read after it is assigned", expr.Sel.Name)
+	}
+
+	if len(hops) != 0 && scope.assigned != nil {
+		p.c.panicWithPosf(expr, "relations cannot be navigated in update function")
+	}
+
+	for _, hop := range hops {
+		if scope.parent != nil {
+			p.c.panicWithPosf(hop, "relations can not be navigated from the subquery parameter")
+		}
+
+		helper = "goquery.Join(" + helper + ", " + p.query + ", \"" + hop.Sel.Name + "\")"
+	}
+
+	return NewColumn(helper, expr.Sel.Name), true
+}
+
+// parseRelationPath returns name of the relation returned from
+// the lambda, with names of relations it is selected through.
+func (p *whereBodyParser) parseRelationPath(body *ast.BlockStmt) string {
+	returnStmt, ok := body.List[0].(*ast.ReturnStmt)
+	if !ok {
+		p.c.panicWithPosf(body.List[0], "relation selector is expected to only have single return statement")
+	}
+
+	expr, ok := returnStmt.Results[0].(*ast.SelectorExpr)
+	if !ok {
+		p.c.panicWithPosf(returnStmt.Results[0], "relation selector must return field of the parameter")
+	}
+
+	if scope, ok := p.scopeOf(expr); !ok || scope != p {
+		p.c.panicWithPosf(expr, "relation selector must return field of the parameter")
+	}
+
+	if !p.isRelationField(expr) {
+		p.c.panicWithPosf(expr, "field %s is not a bun relation", expr.Sel.Name)
+	}
+
+	hops := p.relationHops(expr)
+
+	names := make([]string, 0, len(hops)+1)
+	for _, hop := range hops {
+		names = append(names, hop.Sel.Name)
+	}
+
+	return strings.Join(append(names, expr.Sel.Name), ".")
+}
+
+// relationHops returns selectors of relation fields
+// that lead from the parameter to the selected field.
+func (p *whereBodyParser) relationHops(expr *ast.SelectorExpr) []*ast.SelectorExpr {
+	var hops []*ast.SelectorExpr
+
+	for selector, ok := p.selectorBase(expr.X).(*ast.SelectorExpr); ok; selector, ok = p.selectorBase(selector.X).(*ast.SelectorExpr) {
+		if p.isRelationField(selector) {
+			hops = append([]*ast.SelectorExpr{selector}, hops...)
+		}
+	}
+
+	return hops
+}
+
+// isRelationField reports whether selected field
+// has bun relation(or many-to-many relation)
+// defined in its tag.
+func (p *whereBodyParser) isRelationField(expr *ast.SelectorExpr) bool {
+	selection, ok := p.c.TypeInfo.Selections[expr]
+	if !ok || selection.Kind() != types.FieldVal {
+		return false
+	}
+
+	recv := selection.Recv()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+
+	structType, ok := recv.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+
+	indices := selection.Index()
+	if len(indices) != 1 {
+		return false
+	}
+
+	tag := reflect.StructTag(structType.Tag(indices[0])).Get("bun")
+	for _, option := range strings.Split(tag, ",") {
+		if strings.HasPrefix(option, "rel:") || strings.HasPrefix(option, "m2m:") {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (p *whereBodyParser) parseBinaryExpression(expr *ast.BinaryExpr) Addable {
@@ -206,14 +1481,14 @@ func (p *whereBodyParser) parseBinaryExpression(expr *ast.BinaryExpr) Addable {
 }
 
 func (p *whereBodyParser) parseSelectorExpression(expr *ast.SelectorExpr) Addable {
-	receiver, ok := expr.X.(*ast.Ident)
-	if !ok || receiver.Name != p.paramName {
+	column, ok := p.column(expr)
+	if !ok {
 		p.c.panicWithPosf(expr, "only possible to to select field from parameter")
 		return nil
 	}
 
 	cmp := binary{
-		Left:  NewColumn(expr.Sel.Name),
+		Left:  column,
 		Right: NewSimple(param, true),
 		Op:    tokenToOperation(token.EQL),
 	}
@@ -0,0 +1,118 @@
+package internal
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+)
+
+// Diagnostic is a problem that prevents
+// generating query from the source.
+type Diagnostic struct {
+	Pos, End token.Pos
+	// Position is the position of Pos in the file set.
+	Position token.Position
+	// Expr is the source of the offending
+	// expression, empty for statements.
+	Expr    string
+	Message string
+}
+
+// Error returns the diagnostic in `file:line:col: message` format.
+func (d Diagnostic) Error() string {
+	if d.Expr != "" {
+		return fmt.Sprintf("%s: %s (expr: `%s`)", d.Position, d.Message, d.Expr)
+	}
+
+	return fmt.Sprintf("%s: %s", d.Position, d.Message)
+}
+
+// Diagnostics are all problems found while generating
+// queries, nothing is generated if there are any.
+type Diagnostics []Diagnostic
+
+// Error returns diagnostics, one per line.
+func (d Diagnostics) Error() string {
+	lines := make([]string, 0, len(d))
+	for _, diagnostic := range d {
+		lines = append(lines, diagnostic.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (d *Diagnostics) report(diagnostic Diagnostic) {
+	*d = append(*d, diagnostic)
+}
+
+// sorted returns diagnostics sorted by their position.
+func (d Diagnostics) sorted() Diagnostics {
+	slices.SortFunc(d, func(a, b Diagnostic) int {
+		return cmp.Or(
+			strings.Compare(a.Position.Filename, b.Position.Filename),
+			cmp.Compare(a.Position.Offset, b.Position.Offset),
+		)
+	})
+
+	return d
+}
+
+// diagnosticf returns diagnostic of the node.
+func (c *Context) diagnosticf(node ast.Node, msg string, args ...any) Diagnostic {
+	diagnostic := Diagnostic{
+		Pos:      node.Pos(),
+		End:      node.End(),
+		Position: c.FileSet.Position(node.Pos()),
+		Message:  fmt.Sprintf(msg, args...),
+	}
+
+	if expr, ok := node.(ast.Expr); ok {
+		diagnostic.Expr = c.exprString(expr)
+	}
+
+	return diagnostic
+}
+
+// exprString returns source of the expression.
+//
+// If the expression cannot be printed, its shortened
+// form is returned, as it is only used in messages.
+func (c *Context) exprString(expr ast.Expr) string {
+	var b bytes.Buffer
+
+	if err := printer.Fprint(&b, c.FileSet, expr); err != nil {
+		return types.ExprString(expr)
+	}
+
+	return b.String()
+}
+
+func (c *Context) panicWithPosf(node ast.Node, msg string, args ...any) {
+	panic(c.diagnosticf(node, msg, args...))
+}
+
+// recoverDiagnostic reports diagnostic the generation
+// of the node panicked with, if diagnostics are reported.
+//
+// Other panics are bugs of the generator,
+// they are not recovered.
+func (c *Context) recoverDiagnostic(recovered any) bool {
+	if c.Report == nil {
+		return false
+	}
+
+	diagnostic, ok := recovered.(Diagnostic)
+	if !ok {
+		return false
+	}
+
+	c.Report(diagnostic)
+
+	return true
+}
@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"strings"
+)
+
+// Dialect names, as they are reported by bun dialects.
+const (
+	DialectPG     = "pg"
+	DialectSQLite = "sqlite"
+	DialectMySQL  = "mysql"
+	DialectMSSQL  = "mssql"
+)
+
+// dialects queries are rendered for.
+//
+// The first one is the default, other dialects get
+// their own variant only if it differs from the default.
+var dialects = []string{DialectPG, DialectSQLite, DialectMySQL, DialectMSSQL}
+
+// concat returns concatenation of string expressions.
+//
+// `||` is a logical OR in MySQL, so CONCAT is used instead.
+func concat(dialect string, parts ...string) string {
+	switch dialect {
+	case DialectMySQL, DialectMSSQL:
+		return "CONCAT(" + strings.Join(parts, ", ") + ")"
+	default:
+		return strings.Join(parts, " || ")
+	}
+}
+
+// now returns current time.
+func now(dialect string) string {
+	switch dialect {
+	case DialectSQLite:
+		return sqliteTime("'now'")
+	case DialectMSSQL:
+		return "SYSDATETIME()"
+	default:
+		return "NOW()"
+	}
+}
+
+// sqliteTime returns time computed by SQLite date function
+// with the arguments, in the format bun stores time in,
+// so it could be compared with the stored values as text:
+// in UTC, with fractional seconds without trailing zeros.
+func sqliteTime(args string) string {
+	return "(rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', " + args + "), '0'), '.') || '+00:00')"
+}
+
+// castToFloat converts numeric expression to a floating point number.
+func castToFloat(dialect, expr string) string {
+	switch dialect {
+	case DialectMySQL:
+		return "CAST(" + expr + " AS DOUBLE)"
+	case DialectMSSQL:
+		return "CAST(" + expr + " AS FLOAT)"
+	default:
+		return "CAST(" + expr + " AS DOUBLE PRECISION)"
+	}
+}
+
+// truncate converts floating point number to an
+// integer, dropping its fractional part.
+func truncate(dialect, expr string) string {
+	switch dialect {
+	case DialectSQLite:
+		return "CAST(" + expr + " AS INTEGER)"
+	case DialectMySQL:
+		return "CAST(TRUNCATE(" + expr + ", 0) AS SIGNED)"
+	case DialectMSSQL:
+		return "CAST(" + expr + " AS BIGINT)"
+	default:
+		return "CAST(TRUNC(" + expr + ") AS BIGINT)"
+	}
+}
+
+// concatenation is a concatenation of string addables.
+type concatenation struct {
+	dialect string
+	parts   []Addable
+}
+
+func (c concatenation) String() string {
+	parts := make([]string, 0, len(c.parts))
+	for _, part := range c.parts {
+		parts = append(parts, part.String())
+	}
+
+	return concat(c.dialect, parts...)
+}
+
+func (c concatenation) Args() []any {
+	var args []any
+
+	for _, part := range c.parts {
+		args = append(args, part.Args()...)
+	}
+
+	return args
+}
@@ -0,0 +1,392 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"golang.org/x/tools/go/packages"
+)
+
+// GenerateFile generates queries of a single Go file.
+//
+// If some calls cannot be generated, nothing is written
+// and Diagnostics of all of them are returned.
+func GenerateFile(filePath string) error {
+	var diagnostics Diagnostics
+
+	c := Context{
+		Data:   map[string]EntityCalls{},
+		Report: diagnostics.report,
+	}
+
+	if err := c.ParseFile(filePath); err != nil {
+		return err
+	}
+
+	ast.Walk(&c, c.AstFile)
+
+	if len(diagnostics) != 0 {
+		return diagnostics.sorted()
+	}
+
+	content, err := renderFile(&c)
+	if err != nil {
+		return err
+	}
+
+	files := map[string][]byte{createdBaseTplFilePath(filePath): content}
+	if err := skipHandWritten(c.FileSet, files); err != nil {
+		return err
+	}
+
+	return writeFiles(files)
+}
+
+// GeneratePackages generates queries of all files of the packages
+// matching the patterns, i.e. `./...`. Packages are loaded only once.
+//
+// If perPackage is set a single file is generated for all
+// files of a package(and one more for its tests), otherwise
+// generated file is written next to each file with queries.
+//
+// If some calls cannot be generated, nothing is written
+// and Diagnostics of all of them are returned.
+func GeneratePackages(patterns []string, perPackage bool) error {
+	files, err := generatePackages(patterns, perPackage)
+	if err != nil {
+		return err
+	}
+
+	return writeFiles(files)
+}
+
+// writeFiles writes the files, and removes those with nil content.
+//
+// Content is written to temporary files first, so a failed
+// write leaves no partially updated files. Only then they
+// are renamed over the generated files.
+func writeFiles(files map[string][]byte) error {
+	temporary := make(map[string]string, len(files))
+
+	defer func() {
+		for _, tempPath := range temporary {
+			_ = os.Remove(tempPath)
+		}
+	}()
+
+	for generatedPath, content := range files {
+		if content == nil {
+			continue
+		}
+
+		tempPath, err := writeTemp(generatedPath, content)
+		if err != nil {
+			return err
+		}
+
+		temporary[generatedPath] = tempPath
+	}
+
+	for generatedPath, tempPath := range temporary {
+		if err := os.Rename(tempPath, generatedPath); err != nil {
+			return err
+		}
+
+		delete(temporary, generatedPath)
+	}
+
+	for generatedPath, content := range files {
+		if content != nil {
+			continue
+		}
+
+		if err := os.Remove(generatedPath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeTemp writes content to a temporary file
+// in the directory of the path, and returns its path.
+func writeTemp(filePath string, content []byte) (string, error) {
+	file, err := os.CreateTemp(filepath.Dir(filePath), "."+filepath.Base(filePath)+".*")
+	if err != nil {
+		return "", err
+	}
+
+	_, err = file.Write(content)
+	if err == nil {
+		err = file.Chmod(0644)
+	}
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(file.Name())
+
+		return "", err
+	}
+
+	return file.Name(), nil
+}
+
+// CheckPackages generates queries of the packages in memory
+// and compares them with generated files on disk. Differences
+// are written to w, and number of stale files is returned.
+//
+// Generated files that do not correspond to any source file
+// are stale too, as their call sites no longer exist.
+func CheckPackages(w io.Writer, patterns []string, perPackage bool) (int, error) {
+	files, err := generatePackages(patterns, perPackage)
+	if err != nil {
+		return 0, err
+	}
+
+	generatedPaths := make([]string, 0, len(files))
+	for generatedPath := range files {
+		generatedPaths = append(generatedPaths, generatedPath)
+	}
+
+	slices.Sort(generatedPaths)
+
+	var stale int
+
+	for _, generatedPath := range generatedPaths {
+		actual, err := os.ReadFile(generatedPath)
+		if err != nil && !os.IsNotExist(err) {
+			return stale, err
+		}
+
+		if bytes.Equal(actual, files[generatedPath]) {
+			continue
+		}
+
+		stale++
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(actual)),
+			B:        difflib.SplitLines(string(files[generatedPath])),
+			FromFile: generatedPath,
+			ToFile:   generatedPath + " (generated)",
+			Context:  3,
+		})
+		if err != nil {
+			return stale, err
+		}
+
+		fmt.Fprintln(w, diff)
+	}
+
+	return stale, nil
+}
+
+// generatePackages generates queries of the packages matching
+// the patterns. It returns content of the generated files by
+// their paths, content is nil if the file must not exist.
+func generatePackages(patterns []string, perPackage bool) (map[string][]byte, error) {
+	fileSet := token.NewFileSet()
+
+	pkgs, err := loadPackages(fileSet, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	var diagnostics Diagnostics
+
+	byTypes := packagesByTypes(pkgs)
+
+	// Non-test files are present both in the package
+	// and in its test variant, they are processed once.
+	processed := map[string]bool{}
+	// Outputs by the path of the file
+	// generated file name is derived from.
+	outputs := map[string]*Context{}
+	existing := map[string]bool{}
+
+	for _, pkg := range pkgs {
+		// Test main packages are generated by the go tool.
+		if strings.HasSuffix(pkg.ID, ".test") {
+			continue
+		}
+
+		for i, filePath := range pkg.CompiledGoFiles {
+			if isGeneratedFile(filePath) {
+				existing[filePath] = true
+				continue
+			}
+
+			if processed[filePath] {
+				continue
+			}
+
+			processed[filePath] = true
+
+			outputPath := filePath
+			if perPackage {
+				outputPath = packageOutputPath(filePath, pkg.Syntax[i].Name.Name)
+			}
+
+			c, ok := outputs[outputPath]
+			if !ok {
+				c = &Context{
+					FileSet:  fileSet,
+					Data:     map[string]EntityCalls{},
+					Packages: byTypes,
+					Report:   diagnostics.report,
+				}
+				outputs[outputPath] = c
+			}
+
+			c.TypeInfo = pkg.TypesInfo
+			c.Files = pkg.Syntax
+			c.PackagePath = packagePath(pkg, filePath)
+			ast.Walk(c, pkg.Syntax[i])
+		}
+	}
+
+	if len(diagnostics) != 0 {
+		return nil, diagnostics.sorted()
+	}
+
+	files := make(map[string][]byte, len(outputs))
+	for outputPath, c := range outputs {
+		if files[createdBaseTplFilePath(outputPath)], err = renderFile(c); err != nil {
+			return nil, err
+		}
+	}
+
+	// Generated files of removed source files, or
+	// of the other layout of the generated files.
+	for generatedPath := range existing {
+		if _, ok := files[generatedPath]; !ok {
+			files[generatedPath] = nil
+		}
+	}
+
+	if err := skipHandWritten(fileSet, files); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// renderFile returns content of the file generated for the context.
+// Content is nil if there are no queries, so file left from the
+// time there were queries is removed.
+func renderFile(c *Context) ([]byte, error) {
+	if len(c.Data) == 0 {
+		return nil, nil
+	}
+
+	return RenderBase(c)
+}
+
+// skipHandWritten drops removals of the files that were not
+// generated, as name of the file written by hand may have the suffix too.
+func skipHandWritten(fileSet *token.FileSet, files map[string][]byte) error {
+	for generatedPath, content := range files {
+		if content != nil {
+			continue
+		}
+
+		generated, err := isGeneratedOnDisk(fileSet, generatedPath)
+		if err != nil {
+			return err
+		}
+
+		if !generated {
+			delete(files, generatedPath)
+		}
+	}
+
+	return nil
+}
+
+// isGeneratedOnDisk reports whether the file exists and has
+// `// Code generated ... DO NOT EDIT.` comment before the package clause.
+func isGeneratedOnDisk(fileSet *token.FileSet, filePath string) (bool, error) {
+	file, err := parser.ParseFile(fileSet, filePath, nil, parser.PackageClauseOnly|parser.ParseComments)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return ast.IsGenerated(file), nil
+}
+
+// packageOutputPath returns path of the file that generated
+// file name of the package is derived from. Test files
+// have separate generated file, as they may belong
+// to the external test package.
+func packageOutputPath(filePath, packageName string) string {
+	name := packageName + ".go"
+	if strings.HasSuffix(filePath, "_test.go") {
+		name = packageName + "_test.go"
+	}
+
+	return filepath.Join(filepath.Dir(filePath), name)
+}
+
+// packagePath returns module-relative path of the directory of the file.
+//
+// Import path of the package is not used, as external
+// test packages have `_test` suffix in it.
+func packagePath(pkg *packages.Package, filePath string) string {
+	if pkg.Module == nil {
+		return strings.TrimSuffix(pkg.PkgPath, "_test")
+	}
+
+	rel, err := filepath.Rel(pkg.Module.Dir, filepath.Dir(filePath))
+	if err != nil {
+		panic(err)
+	}
+
+	return path.Join(pkg.Module.Path, filepath.ToSlash(rel))
+}
+
+func isGeneratedFile(filePath string) bool {
+	return strings.HasSuffix(filePath, "_goquery.go") || strings.HasSuffix(filePath, "_goquery_test.go")
+}
+
+// packagesByTypes returns the packages and
+// all of their dependencies by their types.
+func packagesByTypes(pkgs []*packages.Package) map[*types.Package]*packages.Package {
+	byTypes := map[*types.Package]*packages.Package{}
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		byTypes[pkg.Types] = pkg
+	})
+
+	return byTypes
+}
+
+func loadPackages(fileSet *token.FileSet, patterns ...string) ([]*packages.Package, error) {
+	pkgs, err := packages.Load(&packages.Config{
+		Tests: true,
+		Fset:  fileSet,
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedSyntax |
+			packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps | packages.NeedModule,
+	}, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("load packages: %w", err)
+	}
+
+	return pkgs, nil
+}
@@ -0,0 +1,165 @@
+package internal_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ffenix113/goquery/internal"
+)
+
+func TestGeneratePackages(t *testing.T) {
+	dir, err := filepath.Abs("testdata/generate")
+	require.NoError(t, err)
+
+	generated := func(t *testing.T) []string {
+		matches, err := filepath.Glob(filepath.Join(dir, "*_goquery.go"))
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			// Files may be removed by later generation.
+			for _, match := range matches {
+				if err := os.Remove(match); !os.IsNotExist(err) {
+					require.NoError(t, err)
+				}
+			}
+		})
+
+		names := make([]string, 0, len(matches))
+		for _, match := range matches {
+			names = append(names, filepath.Base(match))
+		}
+
+		return names
+	}
+
+	t.Run("per file", func(t *testing.T) {
+		require.NoError(t, internal.GeneratePackages([]string{"./testdata/generate/..."}, false))
+
+		assert.ElementsMatch(t, []string{"orders_goquery.go", "users_goquery.go"}, generated(t))
+	})
+
+	t.Run("per package", func(t *testing.T) {
+		require.NoError(t, internal.GeneratePackages([]string{"./testdata/generate"}, true))
+
+		assert.Equal(t, []string{"generate_goquery.go"}, generated(t))
+
+		content, err := os.ReadFile(filepath.Join(dir, "generate_goquery.go"))
+		require.NoError(t, err)
+
+		assert.Contains(t, string(content), `goquery.AddToGlobalEntity[*User]`)
+		assert.Contains(t, string(content), `goquery.AddToGlobalEntity[*Order]`)
+	})
+
+	t.Run("stale file is removed", func(t *testing.T) {
+		stale := filepath.Join(dir, "plain_goquery.go")
+		require.NoError(t, os.WriteFile(stale, []byte(generatedHeader+"package generate\n"), 0644))
+
+		require.NoError(t, internal.GeneratePackages([]string{"./testdata/generate"}, false))
+
+		assert.NoFileExists(t, stale)
+		generated(t)
+	})
+
+	t.Run("file written by hand is kept", func(t *testing.T) {
+		handWritten := filepath.Join(dir, "plain_goquery.go")
+		require.NoError(t, os.WriteFile(handWritten, []byte("package generate\n"), 0644))
+
+		require.NoError(t, internal.GeneratePackages([]string{"./testdata/generate"}, false))
+
+		assert.ElementsMatch(t, []string{"orders_goquery.go", "plain_goquery.go", "users_goquery.go"}, generated(t))
+	})
+
+	t.Run("single file", func(t *testing.T) {
+		stale := filepath.Join(dir, "plain_goquery.go")
+		require.NoError(t, os.WriteFile(stale, []byte(generatedHeader+"package generate\n"), 0644))
+
+		// Same as `go generate` runs it for each file.
+		for _, name := range []string{"orders.go", "plain.go", "users.go"} {
+			require.NoError(t, internal.GenerateFile(filepath.Join(dir, name)))
+		}
+
+		assert.ElementsMatch(t, []string{"orders_goquery.go", "users_goquery.go"}, generated(t))
+
+		var out bytes.Buffer
+		staleFiles, err := internal.CheckPackages(&out, []string{"./testdata/generate"}, false)
+		require.NoError(t, err)
+		assert.Equal(t, 0, staleFiles, out.String())
+	})
+
+	t.Run("files of other layout are removed", func(t *testing.T) {
+		require.NoError(t, internal.GeneratePackages([]string{"./testdata/generate"}, false))
+		require.NoError(t, internal.GeneratePackages([]string{"./testdata/generate"}, true))
+
+		assert.Equal(t, []string{"generate_goquery.go"}, generated(t))
+
+		require.NoError(t, internal.GeneratePackages([]string{"./testdata/generate"}, false))
+
+		assert.ElementsMatch(t, []string{"orders_goquery.go", "users_goquery.go"}, generated(t))
+	})
+}
+
+const generatedHeader = "// Code generated by goquery; DO NOT EDIT.\n\n"
+
+func TestGenerateDiagnostics(t *testing.T) {
+	file, err := filepath.Abs("testdata/invalid/invalid.go")
+	require.NoError(t, err)
+
+	err = internal.GeneratePackages([]string{"./testdata/invalid"}, false)
+
+	var diagnostics internal.Diagnostics
+	require.ErrorAs(t, err, &diagnostics)
+
+	assert.Equal(t, file+":18:91: Where calls on the same line cannot be told apart, chain them or put them on separate lines (expr: `Where`)\n"+
+		file+":26:55: argument is not provided: f.name (expr: `f.name`)\n"+
+		file+":30:55: function strconv.FormatInt is not supported (expr: `strconv.FormatInt(id, 10)`)\n"+
+		file+":35:53: argument is not provided: ids[0] (expr: `ids[0]`)\n"+
+		file+":39:45: conversion from int64 to int8 is not supported, as it may change the value (expr: `int8(u.ID)`)\n"+
+		file+":45:12: field ID cannot be read after it is assigned (expr: `u.ID`)\n"+
+		file+":50:45: operator % is not supported (expr: `u.ID % 2`)\n"+
+		file+":61:55: argument is not provided: rank (expr: `rank`)", err.Error())
+
+	// Valid calls are not generated either.
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(file), "invalid_goquery.go"))
+}
+
+func TestCheckPackages(t *testing.T) {
+	dir, err := filepath.Abs("testdata/generate")
+	require.NoError(t, err)
+
+	patterns := []string{"./testdata/generate"}
+
+	var out bytes.Buffer
+	stale, err := internal.CheckPackages(&out, patterns, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stale)
+	assert.Contains(t, out.String(), "+++ "+filepath.Join(dir, "users_goquery.go")+" (generated)")
+
+	require.NoError(t, internal.GeneratePackages(patterns, false))
+	t.Cleanup(func() {
+		for _, name := range []string{"orders_goquery.go", "users_goquery.go"} {
+			require.NoError(t, os.Remove(filepath.Join(dir, name)))
+		}
+	})
+
+	out.Reset()
+	stale, err = internal.CheckPackages(&out, patterns, false)
+	require.NoError(t, err)
+	assert.Equal(t, 0, stale)
+	assert.Empty(t, out.String())
+
+	// Generated file of the removed source file.
+	orphan := filepath.Join(dir, "removed_goquery.go")
+	require.NoError(t, os.WriteFile(orphan, []byte(generatedHeader+"package generate\n"), 0644))
+	t.Cleanup(func() { require.NoError(t, os.Remove(orphan)) })
+
+	out.Reset()
+	stale, err = internal.CheckPackages(&out, patterns, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stale)
+	assert.Contains(t, out.String(), "--- "+orphan)
+}
@@ -0,0 +1,135 @@
+// Code generated by goquery; DO NOT EDIT.
+
+package internal_test
+
+import (
+	"github.com/ffenix113/goquery"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	goquery.RegisterPackagePath("github.com/ffenix113/goquery/internal")
+	goquery.AddToGlobalEntity[*Extensive](
+		goquery.Calls{
+			Where: map[goquery.Caller]goquery.QueryFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/goquerytest_test.go", Line: 36}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? / ? = -? OR ? < ? AND ? != ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							2,
+							1,
+							goquery.Column(helper, "StringCol"),
+							"B",
+							goquery.Column(helper, "StringCol2"),
+							""}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/goquerytest_test.go", Line: 43}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where(goquery.DialectQuery(query, "CAST(TRUNC(CAST(? AS DOUBLE PRECISION) / ?) AS BIGINT) = -?", map[string]string{
+						"mssql":  "CAST(CAST(? AS FLOAT) / ? AS BIGINT) = -?",
+						"mysql":  "CAST(TRUNCATE(CAST(? AS DOUBLE) / ?, 0) AS SIGNED) = -?",
+						"sqlite": "CAST(CAST(? AS DOUBLE PRECISION) / ? AS INTEGER) = -?",
+					}),
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							2,
+							1}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/goquerytest_test.go", Line: 47}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? >= ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							0}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/goquerytest_test.go", Line: 48}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("lower(?) != ?",
+						[]any{
+							goquery.Column(helper, "StringCol2"),
+							"b"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/goquerytest_test.go", Line: 52}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("not (? < ?) AND ((lower(?) = ?) AND (? != ?) OR not (lower(?) = ?) AND (? > ?))",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							0,
+							goquery.Column(helper, "StringCol"),
+							"a",
+							goquery.Column(helper, "StringCol2"),
+							"",
+							goquery.Column(helper, "StringCol"),
+							"a",
+							goquery.Column(helper, "IntCol"),
+							1}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/goquerytest_test.go", Line: 65}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("((? = ?) OR (? = ?)) AND (? >= ?) OR not ((? = ?) OR (? = ?)) AND (? = ?)",
+						[]any{
+							goquery.Column(helper, "StringCol2"),
+							"B",
+							goquery.Column(helper, "StringCol2"),
+							"b",
+							goquery.Column(helper, "IntCol"),
+							0,
+							goquery.Column(helper, "StringCol2"),
+							"B",
+							goquery.Column(helper, "StringCol2"),
+							"b",
+							goquery.Column(helper, "StringCol2"),
+							""}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/goquerytest_test.go", Line: 89}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where(goquery.DialectQuery(query, "? + INTERVAL '1 millisecond' > ?", map[string]string{
+						"mssql":  "DATEADD(millisecond, 1, ?) > ?",
+						"mysql":  "DATE_ADD(?, INTERVAL (1) * 1000 MICROSECOND) > ?",
+						"sqlite": "(rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', ?, (1 / 1000.0) || ' seconds'), '0'), '.') || '+00:00') > ?",
+					}),
+						[]any{
+							goquery.Column(helper, "TimeCol"),
+							goquery.Column(helper, "TimeCol")}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/goquerytest_test.go", Line: 93}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where(goquery.DialectQuery(query, "? + INTERVAL '1 hour' < NOW()", map[string]string{
+						"mssql":  "DATEADD(hour, 1, ?) < SYSDATETIME()",
+						"mysql":  "DATE_ADD(?, INTERVAL 1 HOUR) < NOW()",
+						"sqlite": "(rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', ?, '+1 hour'), '0'), '.') || '+00:00') < (rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', 'now'), '0'), '.') || '+00:00')",
+					}),
+						[]any{
+							goquery.Column(helper, "TimeCol")}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/goquerytest_test.go", Line: 105}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? > ?",
+						[]any{
+							goquery.Column(helper, "TimeCol"),
+							args[0]}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/goquerytest_test.go", Line: 112}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("upper(?) = ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							goquery.Column(helper, "StringCol2")}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/goquerytest_test.go", Line: 132}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? > ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							args[0]}...)
+				},
+			},
+			OrderBy: map[goquery.Caller]goquery.ExprFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/goquerytest_test.go", Line: 133}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "StringCol")}
+				},
+			},
+		},
+	)
+}
@@ -0,0 +1,186 @@
+//go:generate go run ../cmd/goquery/main.go
+
+package internal_test
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ffenix113/goquery"
+	"github.com/ffenix113/goquery/goquerytest"
+)
+
+func init() {
+	goquerytest.RegisterUpdateFlag()
+}
+
+func TestDifferential(t *testing.T) {
+	db := getDB(t)
+
+	rows := []*Extensive{
+		{StringCol: "a", StringCol2: "B", IntCol: -3},
+		{StringCol: "A", StringCol2: "b", IntCol: 0},
+		{StringCol: "ab", StringCol2: "", IntCol: 7},
+		{StringCol: "é", StringCol2: "É", IntCol: 2},
+	}
+
+	t.Run("same", func(t *testing.T) {
+		goquerytest.Compare(t, db, rows, func(q goquery.Queryable[*Extensive]) goquery.Queryable[*Extensive] {
+			return q.Where(func(e *Extensive) bool {
+				return e.IntCol/2 == -1 || e.StringCol < "B" && e.StringCol2 != ""
+			})
+		})
+
+		goquerytest.Compare(t, db, rows, func(q goquery.Queryable[*Extensive]) goquery.Queryable[*Extensive] {
+			// Conversion to int truncates towards zero.
+			return q.Where(func(e *Extensive) bool { return int(float64(e.IntCol)/2) == -1 })
+		})
+
+		goquerytest.Compare(t, db, rows, func(q goquery.Queryable[*Extensive]) goquery.Queryable[*Extensive] {
+			return q.Where(func(e *Extensive) bool { return e.IntCol >= 0 }).
+				Where(func(e *Extensive) bool { return strings.ToLower(e.StringCol2) != "b" })
+		})
+
+		goquerytest.Compare(t, db, rows, func(q goquery.Queryable[*Extensive]) goquery.Queryable[*Extensive] {
+			return q.Where(func(e *Extensive) bool {
+				lower := strings.ToLower(e.StringCol)
+				if e.IntCol < 0 {
+					return false
+				} else if lower == "a" {
+					return e.StringCol2 != ""
+				}
+
+				return e.IntCol > 1
+			})
+		})
+
+		goquerytest.Compare(t, db, rows, func(q goquery.Queryable[*Extensive]) goquery.Queryable[*Extensive] {
+			return q.Where(func(e *Extensive) bool {
+				switch e.StringCol2 {
+				case "B", "b":
+					return e.IntCol >= 0
+				case "":
+					return true
+				}
+
+				return false
+			})
+		})
+	})
+
+	t.Run("time", func(t *testing.T) {
+		zone := time.FixedZone("CET", 3600)
+		rows := []*Extensive{
+			{TimeCol: time.Date(2024, 1, 1, 10, 0, 0, 0, zone)},
+			{TimeCol: time.Date(2024, 1, 1, 10, 0, 0, 500_000_000, zone)},
+			{TimeCol: time.Now().Add(-2 * time.Hour)},
+			{TimeCol: time.Now().Add(2 * time.Hour)},
+		}
+
+		// Times are compared as text in SQLite.
+		goquerytest.Compare(t, db, rows, func(q goquery.Queryable[*Extensive]) goquery.Queryable[*Extensive] {
+			return q.Where(func(e *Extensive) bool { return e.TimeCol.Add(time.Millisecond).After(e.TimeCol) })
+		})
+
+		goquerytest.Compare(t, db, rows, func(q goquery.Queryable[*Extensive]) goquery.Queryable[*Extensive] {
+			return q.Where(func(e *Extensive) bool { return e.TimeCol.Add(time.Hour).Before(time.Now()) })
+		})
+	})
+
+	t.Run("stored values", func(t *testing.T) {
+		// Rows are evaluated in Go as they are stored,
+		// and bun stores times with microsecond precision.
+		base := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+		since := base.Add(500 * time.Nanosecond)
+		rows := []*Extensive{{TimeCol: base.Add(900 * time.Nanosecond)}}
+
+		goquerytest.Compare(t, db, rows, func(q goquery.Queryable[*Extensive]) goquery.Queryable[*Extensive] {
+			return q.Where(func(e *Extensive) bool { return e.TimeCol.After(since) }, since)
+		})
+	})
+
+	t.Run("different", func(t *testing.T) {
+		mismatches, err := goquerytest.Diff(context.Background(), db, rows, func(q goquery.Queryable[*Extensive]) goquery.Queryable[*Extensive] {
+			// upper() of SQLite changes only ASCII letters.
+			return q.Where(func(e *Extensive) bool { return strings.ToUpper(e.StringCol) == e.StringCol2 })
+		})
+		require.NoError(t, err)
+		require.Len(t, mismatches, 1)
+
+		mismatch := mismatches[0]
+		assert.Equal(t, "é", mismatch.Row.StringCol)
+		assert.False(t, mismatch.InSQL)
+		assert.True(t, mismatch.InGo)
+		assert.Equal(t, `SELECT "extensive"."string_col", "extensive"."string_col2", "extensive"."int_col", "extensive"."time_col" FROM "extensives" AS "extensive" WHERE (upper("string_col") = "string_col2")`, mismatch.SQL)
+	})
+}
+
+func TestAssertSQL(t *testing.T) {
+	db := getDB(t)
+	factory := goquery.NewFactory[*Extensive](db)
+
+	newQuery := func() goquery.Queryable[*Extensive] {
+		minVal := 5
+		return factory.New(db.NewSelect().Model((*Extensive)(nil))).
+			Where(func(e *Extensive) bool { return e.IntCol > minVal }, minVal).
+			OrderBy(func(e *Extensive) any { return e.StringCol })
+	}
+
+	const wantSQL = `SELECT "extensive"."string_col", "extensive"."string_col2", "extensive"."int_col", "extensive"."time_col" ` +
+		`FROM "extensives" AS "extensive" WHERE ("int_col" > 5) ORDER BY "string_col" ASC`
+
+	t.Run("sql", func(t *testing.T) {
+		assert.True(t, goquerytest.AssertSQL(t, newQuery(), wantSQL))
+
+		var fake fakeTB
+		assert.False(t, goquerytest.AssertSQL(&fake, newQuery(), wantSQL, 5))
+		assert.False(t, goquerytest.AssertSQL(&fake, newQuery(), `SELECT 1`))
+		require.Len(t, fake.errors, 2)
+		assert.Contains(t, fake.errors[0], "unexpected args")
+		assert.Contains(t, fake.errors[1], "unexpected SQL")
+	})
+
+	t.Run("update flag", func(t *testing.T) {
+		updateFlag := flag.Lookup("update")
+		require.NotNil(t, updateFlag)
+
+		// Flag that is already defined is kept.
+		assert.NotPanics(t, goquerytest.RegisterUpdateFlag)
+		assert.Same(t, updateFlag, flag.Lookup("update"))
+	})
+
+	t.Run("golden", func(t *testing.T) {
+		// Failing assertions below would be written with -update.
+		if goquerytest.Update {
+			t.Skip("golden files of the test are not updated")
+		}
+
+		assert.True(t, goquerytest.AssertGolden(t, newQuery(), "testdata/golden/where.golden"))
+
+		var fake fakeTB
+		assert.False(t, goquerytest.AssertGolden(&fake, newQuery().Take(1), "testdata/golden/where.golden"))
+		assert.False(t, goquerytest.AssertGolden(&fake, newQuery(), "testdata/golden/missing.golden"))
+		require.Len(t, fake.errors, 2)
+		assert.Contains(t, fake.errors[0], "+"+wantSQL+" LIMIT 1")
+		assert.Contains(t, fake.errors[1], "does not exist")
+	})
+}
+
+// fakeTB keeps reported errors instead of failing the test.
+type fakeTB struct {
+	testing.TB
+	errors []string
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,210 @@
+package internal
+
+import (
+	"go/ast"
+	"go/types"
+	"slices"
+)
+
+// addInlineGenerators adds generator of calls to functions
+// and methods declared in the module. Their bodies are
+// inlined into the query, with parameters bound to the
+// arguments of the call.
+//
+// It must be added after other generators of calls,
+// so supported functions are not inlined.
+func addInlineGenerators() {
+	addGenerator(func(p *whereBodyParser, s *ast.CallExpr, args map[string]int) Addable {
+		fn, recv := p.calledFunc(s)
+		if fn == nil {
+			return nil
+		}
+
+		decl := p.c.funcDecl(fn)
+		if decl == nil || decl.Body == nil {
+			return nil
+		}
+
+		return p.inline(s, decl, recv, args)
+	})
+}
+
+// calledFunc returns function or method called in the
+// expression, and expression of the method receiver.
+func (p *whereBodyParser) calledFunc(call *ast.CallExpr) (*types.Func, ast.Expr) {
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		fn, _ := p.c.TypeInfo.Uses[fun].(*types.Func)
+
+		return fn, nil
+	case *ast.SelectorExpr:
+		selection, ok := p.c.TypeInfo.Selections[fun]
+		if !ok {
+			// Function of another package.
+			fn, _ := p.c.TypeInfo.Uses[fun.Sel].(*types.Func)
+
+			return fn, nil
+		}
+
+		if selection.Kind() != types.MethodVal {
+			return nil, nil
+		}
+
+		fn, _ := selection.Obj().(*types.Func)
+
+		return fn, fun.X
+	}
+
+	return nil, nil
+}
+
+// funcDecl returns declaration of the function if it is declared
+// in one of the package files, or in other package of the module.
+//
+// Syntax of other packages is loaded only by the generator, so
+// the analyzer does not inline functions of other packages.
+func (c *Context) funcDecl(fn *types.Func) *ast.FuncDecl {
+	decl, _ := c.declOf(fn).(*ast.FuncDecl)
+
+	return decl
+}
+
+// inline returns value of the function body, with receiver
+// and parameters bound to the expressions of the call.
+func (p *whereBodyParser) inline(call *ast.CallExpr, decl *ast.FuncDecl, recv ast.Expr, args map[string]int) Addable {
+	fn := p.c.TypeInfo.Defs[decl.Name].(*types.Func)
+
+	for scope := p; scope != nil; scope = scope.parent {
+		if slices.Contains(scope.inlining, fn) {
+			p.c.panicWithPosf(call, "function %s cannot be inlined, as it calls itself", fn.Name())
+		}
+	}
+
+	if fn.Signature().Variadic() {
+		p.c.panicWithPosf(call, "variadic function %s cannot be inlined", fn.Name())
+	}
+
+	// Arguments are converted in the scope of the caller,
+	// before the function is marked as being inlined, as
+	// they may call it too, like in `double(double(x))`.
+	values := make([]Addable, len(call.Args))
+	for i, param := range paramTypes(fn) {
+		if bindsValue(param) {
+			values[i] = p.argAddable(call.Args[i], param, args)
+		}
+	}
+
+	p.inlining = append(p.inlining, fn)
+	defer func() { p.inlining = p.inlining[:len(p.inlining)-1] }()
+
+	if p.locals == nil {
+		p.locals = map[types.Object]ast.Expr{}
+	}
+
+	if p.values == nil {
+		p.values = map[types.Object]Addable{}
+	}
+
+	// Parameters are bound only while the body is
+	// inlined, as the function may be called again
+	// with other arguments, i.e. `max(max(a, b), c)`.
+	var restore []func()
+	defer func() {
+		for _, f := range slices.Backward(restore) {
+			f()
+		}
+	}()
+
+	bind := func(name *ast.Ident, expr ast.Expr, value Addable) {
+		obj := p.c.TypeInfo.Defs[name]
+		if obj == nil {
+			return
+		}
+
+		prevExpr, hadExpr := p.locals[obj]
+		prevValue, hadValue := p.values[obj]
+		restore = append(restore, func() {
+			delete(p.locals, obj)
+			delete(p.values, obj)
+
+			if hadExpr {
+				p.locals[obj] = prevExpr
+			}
+
+			if hadValue {
+				p.values[obj] = prevValue
+			}
+		})
+
+		if value != nil {
+			p.values[obj] = value
+		} else {
+			p.locals[obj] = expr
+		}
+	}
+
+	if decl.Recv != nil && len(decl.Recv.List[0].Names) != 0 {
+		bind(decl.Recv.List[0].Names[0], recv, nil)
+	}
+
+	i := 0
+	for _, field := range decl.Type.Params.List {
+		if len(field.Names) == 0 {
+			i++
+			continue
+		}
+
+		for _, name := range field.Names {
+			bind(name, call.Args[i], values[i])
+			i++
+		}
+	}
+
+	return p.caseOf(p.foldBranches(decl.Body.List), func(expr ast.Expr) Addable {
+		return p.localAddable(expr, args)
+	})
+}
+
+func paramTypes(fn *types.Func) []types.Type {
+	params := fn.Signature().Params()
+
+	res := make([]types.Type, 0, params.Len())
+	for i := range params.Len() {
+		res = append(res, params.At(i).Type())
+	}
+
+	return res
+}
+
+// bindsValue reports whether parameter of the type is bound
+// to the value of the argument, instead of its expression.
+//
+// Fields are selected from structs, and functions like
+// goquery.Any are called with slices, so those need the
+// expression of the parameters of the lambda they are from.
+func bindsValue(tp types.Type) bool {
+	if ptr, ok := tp.Underlying().(*types.Pointer); ok {
+		tp = ptr.Elem()
+	}
+
+	if tp.String() == "time.Time" {
+		return true
+	}
+
+	switch tp.Underlying().(type) {
+	case *types.Struct, *types.Slice, *types.Array, *types.Map:
+		return false
+	default:
+		return true
+	}
+}
+
+// argAddable returns Addable of the argument of inlined function.
+// Booleans are converted as conditions, as they are used in filters.
+func (p *whereBodyParser) argAddable(arg ast.Expr, tp types.Type, args map[string]int) Addable {
+	if basic, ok := tp.Underlying().(*types.Basic); ok && basic.Info()&types.IsBoolean != 0 {
+		return Parens{Addable: p.parseCondition(arg)}
+	}
+
+	return p.localAddable(arg, args)
+}
@@ -0,0 +1,245 @@
+// Code generated by goquery; DO NOT EDIT.
+
+package internal_test
+
+import (
+	"github.com/ffenix113/goquery"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	goquery.RegisterPackagePath("github.com/ffenix113/goquery/internal")
+	goquery.AddToGlobalEntity[*Customer](
+		goquery.Calls{
+			Where: map[goquery.Caller]goquery.QueryFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 195}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("EXISTS (SELECT 1 FROM ? WHERE ? AND (? > ?))",
+						[]any{
+							goquery.Relation(helper, "Orders").From,
+							goquery.Relation(helper, "Orders").Condition,
+							goquery.Column(goquery.Relation(helper, "Orders").Helper, "Total"),
+							goquery.Column(goquery.Qualified(helper), "Limit")}...)
+				},
+			},
+			GroupBy: map[goquery.Caller]goquery.ExprFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 182}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "Orders")}
+				},
+			},
+		},
+	)
+
+	goquery.AddToGlobalEntity[*Extensive](
+		goquery.Calls{
+			Where: map[goquery.Caller]goquery.QueryFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 39}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? > ? OR ? = ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							args[0],
+							goquery.Column(helper, "StringCol"),
+							"c"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 94}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? > ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							5}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 97}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? > ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							5}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 101}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							"a"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 104}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							"c"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 108}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							"a"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 112}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(helper, "StringCol2"),
+							"z"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 116}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where(goquery.DialectQuery(query, "not (? > ?) OR (? > ?) IS NULL", map[string]string{
+						"mssql": "CASE WHEN ? > ? THEN 0 ELSE 1 END = 1",
+					}),
+						goquery.DialectArgs(query, []any{
+							goquery.Column(helper, "IntCol"),
+							0,
+							goquery.Column(helper, "IntCol"),
+							0}, map[string][]any{
+							"mssql": {
+								goquery.Column(helper, "IntCol"),
+								0},
+						})...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 121}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where(goquery.DialectQuery(query, "not (? IN (?)) OR (? IN (?)) IS NULL", map[string]string{
+						"mssql": "CASE WHEN ? IN (?) THEN 0 ELSE 1 END = 1",
+					}),
+						goquery.DialectArgs(query, []any{
+							goquery.Column(helper, "IntCol"),
+							bun.In(args[0]),
+							goquery.Column(helper, "IntCol"),
+							bun.In(args[0])}, map[string][]any{
+							"mssql": {
+								goquery.Column(helper, "IntCol"),
+								bun.In(args[0])},
+						})...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 130}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							"a"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 135}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(helper, "StringCol2"),
+							"x"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 147}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? > ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							1}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 159}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Unwrap().(*bun.SelectQuery).Having("count(*) < ?",
+						[]any{
+							2}...)
+				},
+			},
+			OrderBy: map[goquery.Caller]goquery.ExprFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 40}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "StringCol2")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 41}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "IntCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 49}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "StringCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 50}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "IntCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 66}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "CASE WHEN ? > ? THEN ? ELSE ? END", []any{
+						goquery.Column(helper, "IntCol"),
+						1,
+						goquery.Column(helper, "IntCol"),
+						goquery.Column(helper, "StringCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 79}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "StringCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 80}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "IntCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 90}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "IntCol")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 148}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "StringCol")}
+				},
+			},
+			Select: map[goquery.Caller]goquery.ProjectionFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 150}: func(helper goquery.Helper, resultHelper goquery.Helper, query *bun.SelectQuery, args ...any) {
+					query.ColumnExpr("? AS ?, ? * ? AS ?",
+						[]any{
+							goquery.Column(helper, "StringCol"),
+							bun.Ident(resultHelper.ColumnName("Name")),
+							goquery.Column(helper, "IntCol"),
+							goquery.Column(helper, "IntCol"),
+							bun.Ident(resultHelper.ColumnName("Total"))}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 161}: func(helper goquery.Helper, resultHelper goquery.Helper, query *bun.SelectQuery, args ...any) {
+					query.ColumnExpr(goquery.DialectQuery(query, "? AS ?, count(*) AS ?, sum(CAST(? AS DOUBLE PRECISION)) AS ?", map[string]string{
+						"mssql": "? AS ?, count(*) AS ?, sum(CAST(? AS FLOAT)) AS ?",
+						"mysql": "? AS ?, count(*) AS ?, sum(CAST(? AS DOUBLE)) AS ?",
+					}),
+						[]any{
+							goquery.GroupKey(helper),
+							bun.Ident(resultHelper.ColumnName("Name")),
+							bun.Ident(resultHelper.ColumnName("Count")),
+							goquery.Column(helper, "IntCol"),
+							bun.Ident(resultHelper.ColumnName("Total"))}...)
+				},
+			},
+			GroupBy: map[goquery.Caller]goquery.ExprFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 158}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "StringCol")}
+				},
+			},
+			Update: map[goquery.Caller]goquery.UpdateFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 131}: func(helper goquery.Helper, query *bun.UpdateQuery, args ...any) {
+					query.Set("? = ? * ?",
+						[]any{
+							goquery.Column(helper, "IntCol"),
+							goquery.Column(helper, "IntCol"),
+							10}...)
+				},
+			},
+		},
+	)
+
+	goquery.AddToGlobalEntity[*extensiveStats](
+		goquery.Calls{
+			OrderBy: map[goquery.Caller]goquery.ExprFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/memory_test.go", Line: 167}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "Name")}
+				},
+			},
+		},
+	)
+}
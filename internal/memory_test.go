@@ -0,0 +1,203 @@
+//go:generate go run ../cmd/goquery/main.go
+
+package internal_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ffenix113/goquery"
+)
+
+func TestMemoryQueryable(t *testing.T) {
+	ctx := context.Background()
+
+	newFactory := func() goquery.Factory[*Extensive] {
+		return goquery.NewMemoryFactory([]*Extensive{
+			{StringCol: "b", StringCol2: "x", IntCol: 2},
+			{StringCol: "a", StringCol2: "y", IntCol: 3},
+			{StringCol: "c", StringCol2: "x", IntCol: 1},
+			{StringCol: "a", StringCol2: "x", IntCol: 1},
+		})
+	}
+
+	names := func(rows []*Extensive) []string {
+		res := make([]string, 0, len(rows))
+		for _, row := range rows {
+			res = append(res, row.StringCol+row.StringCol2)
+		}
+
+		return res
+	}
+
+	t.Run("where and order", func(t *testing.T) {
+		minVal := 1
+		res, err := newFactory().New().
+			Where(func(e *Extensive) bool { return e.IntCol > minVal || e.StringCol == "c" }, minVal).
+			OrderBy(func(e *Extensive) any { return e.StringCol2 }).
+			ThenByDescending(func(e *Extensive) any { return e.IntCol }).
+			ToSlice(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bx", "cx", "ay"}, names(res))
+	})
+
+	t.Run("paging", func(t *testing.T) {
+		q := newFactory().New().
+			OrderBy(func(e *Extensive) any { return e.StringCol }).
+			ThenBy(func(e *Extensive) any { return e.IntCol }).
+			Skip(1).
+			Take(2)
+
+		res, err := q.ToSlice(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ay", "bx"}, names(res))
+
+		// Same as in SQL, Count counts rows of the page.
+		count, err := q.Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("order by mixed types", func(t *testing.T) {
+		_, err := newFactory().New().
+			OrderBy(func(e *Extensive) any {
+				if e.IntCol > 1 {
+					return e.IntCol
+				}
+
+				return e.StringCol
+			}).
+			ToSlice(ctx)
+		assert.ErrorContains(t, err, "cannot compare values of different types")
+	})
+
+	t.Run("after", func(t *testing.T) {
+		res, err := newFactory().New().
+			OrderBy(func(e *Extensive) any { return e.StringCol }).
+			ThenBy(func(e *Extensive) any { return e.IntCol }).
+			After(&Extensive{StringCol: "a", IntCol: 1}).
+			ToSlice(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ay", "bx", "cx"}, names(res))
+	})
+
+	t.Run("terminal operators", func(t *testing.T) {
+		factory := newFactory()
+
+		first, err := factory.New().OrderBy(func(e *Extensive) any { return e.IntCol }).First(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, first.IntCol)
+
+		_, err = factory.New().Where(func(e *Extensive) bool { return e.IntCol > 5 }).First(ctx)
+		assert.ErrorIs(t, err, goquery.ErrNoRows)
+
+		def, err := factory.New().Where(func(e *Extensive) bool { return e.IntCol > 5 }).FirstOrDefault(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, def)
+
+		_, err = factory.New().Where(func(e *Extensive) bool { return e.StringCol == "a" }).Single(ctx)
+		assert.ErrorIs(t, err, goquery.ErrMultipleRows)
+
+		single, err := factory.New().Where(func(e *Extensive) bool { return e.StringCol == "c" }).Single(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, single.IntCol)
+
+		single, err = factory.New().Where(func(e *Extensive) bool { return e.StringCol == "a" }).Take(1).Single(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "a", single.StringCol)
+
+		exists, err := factory.New().Where(func(e *Extensive) bool { return e.StringCol2 == "z" }).Any(ctx)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		all, err := factory.New().All(ctx, func(e *Extensive) bool { return e.IntCol > 0 })
+		require.NoError(t, err)
+		assert.True(t, all)
+
+		ints := []int{1, 2}
+		all, err = factory.New().All(ctx, func(e *Extensive) bool { return goquery.In(e.IntCol, ints) }, ints)
+		require.NoError(t, err)
+		assert.False(t, all)
+	})
+
+	t.Run("update and delete", func(t *testing.T) {
+		factory := newFactory()
+
+		updated, err := factory.New().
+			Where(func(e *Extensive) bool { return e.StringCol == "a" }).
+			Update(ctx, func(e *Extensive) { e.IntCol = e.IntCol * 10 })
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), updated)
+
+		deleted, err := factory.New().Where(func(e *Extensive) bool { return e.StringCol2 == "x" }).Delete(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), deleted)
+
+		res, err := factory.New().ToSlice(ctx)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, 30, res[0].IntCol)
+	})
+
+	t.Run("select", func(t *testing.T) {
+		q := newFactory().New().
+			Where(func(e *Extensive) bool { return e.IntCol > 1 }).
+			OrderBy(func(e *Extensive) any { return e.StringCol })
+
+		res, err := goquery.Select(q, func(e *Extensive) extensiveDTO {
+			return extensiveDTO{Name: e.StringCol, Total: e.IntCol * e.IntCol}
+		}).ToSlice(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []extensiveDTO{{Name: "a", Total: 9}, {Name: "b", Total: 4}}, res)
+	})
+
+	t.Run("group by", func(t *testing.T) {
+		groups := goquery.GroupBy(newFactory().New(), func(e *Extensive) string { return e.StringCol }).
+			Where(func(g goquery.Group[string, *Extensive]) bool { return g.Count() < 2 })
+
+		res, err := goquery.Select(groups, func(g goquery.Group[string, *Extensive]) extensiveStats {
+			return extensiveStats{
+				Name:  g.Key,
+				Count: g.Count(),
+				Total: g.Sum(func(e *Extensive) float64 { return float64(e.IntCol) }),
+			}
+		}).OrderByDescending(func(s extensiveStats) any { return s.Name }).ToSlice(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []extensiveStats{
+			{Name: "c", Count: 1, Total: 1},
+			{Name: "b", Count: 1, Total: 2},
+		}, res)
+	})
+
+	t.Run("group by non-comparable key", func(t *testing.T) {
+		customers := goquery.NewMemoryFactory([]*Customer{
+			{Name: "a", Orders: []*Order{{Total: 3}}},
+			{Name: "b", Orders: []*Order{{Total: 3}}},
+			{Name: "c"},
+		})
+
+		count, err := goquery.GroupBy(customers.New(), func(c *Customer) any { return c.Orders }).Count(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("relations", func(t *testing.T) {
+		customers := goquery.NewMemoryFactory([]*Customer{
+			{Name: "a", Limit: 5, Orders: []*Order{{Total: 3}, {Total: 7}}},
+			{Name: "b", Limit: 5, Orders: []*Order{{Total: 1}}},
+			{Name: "c"},
+		})
+
+		res, err := customers.New().
+			Where(func(c *Customer) bool {
+				return goquery.Any(c.Orders, func(o *Order) bool { return o.Total > c.Limit })
+			}).
+			ToSlice(ctx)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		assert.Equal(t, "a", res[0].Name)
+	})
+}
@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"fmt"
 	"go/ast"
 )
 
@@ -12,7 +13,7 @@ func (StringsPackage) hasPrefix(p *whereBodyParser, s *ast.CallExpr, args map[st
 		"LIKE",
 		Wrapper{
 			Addable: p.exprToAddable(s.Args[1], args),
-			StringF: func(a Addable) string { return a.String() + ` || '%'` },
+			StringF: func(a Addable) string { return concat(p.dialect, a.String(), `'%'`) },
 		},
 	)
 }
@@ -23,7 +24,7 @@ func (StringsPackage) hasSuffix(p *whereBodyParser, s *ast.CallExpr, args map[st
 		"LIKE",
 		Wrapper{
 			Addable: p.exprToAddable(s.Args[1], args),
-			StringF: func(a Addable) string { return `'%' || ` + a.String() },
+			StringF: func(a Addable) string { return concat(p.dialect, `'%'`, a.String()) },
 		},
 	)
 }
@@ -36,7 +37,7 @@ func (StringsPackage) contains(p *whereBodyParser, s *ast.CallExpr, args map[str
 		Wrapper{
 			Addable: p.exprToAddable(s.Args[1], args),
 			StringF: func(a Addable) string {
-				return `'%' || ` + a.String() + ` || '%'`
+				return concat(p.dialect, `'%'`, a.String(), `'%'`)
 			},
 		},
 	)
@@ -68,9 +69,7 @@ func (GoQueryPackage) isNull(p *whereBodyParser, s *ast.CallExpr, args map[strin
 }
 
 func (GoQueryPackage) in(p *whereBodyParser, s *ast.CallExpr, args map[string]int) Addable {
-	if _, ok := args[p.c.exprName(s.Args[1])]; !ok {
-		p.c.panicWithPosf(s.Args[1], "argument is not present in Where arguments")
-	}
+	slice := p.argValue(s.Args[1], args)
 
 	return Wrapper{
 		Addable: p.exprToAddable(s.Args[0], args),
@@ -78,7 +77,28 @@ func (GoQueryPackage) in(p *whereBodyParser, s *ast.CallExpr, args map[string]in
 			return a.String() + " IN (?)"
 		},
 		ArgsF: func(a Addable) []any {
-			return append(a.Args(), raw("bun.In("+fromArgs(0)+")"))
+			return append(a.Args(), raw("bun.In("+slice+")"))
 		},
 	}
 }
+
+// relation returns generator of the subquery on the related entities.
+//
+// Format must contain a single `%s` for the filter of related entities,
+// which could be negated.
+func (GoQueryPackage) relation(format string, negate bool) typedGenerator[*ast.CallExpr] {
+	return func(p *whereBodyParser, s *ast.CallExpr, args map[string]int) Addable {
+		relation, filter := p.parseRelationFilter(s.Args[0], s.Args[1], args)
+		if negate {
+			filter = NotTrue{Addable: filter, dialect: p.dialect}
+		}
+
+		return Wrapper{
+			Addable: filter,
+			StringF: func(a Addable) string { return fmt.Sprintf(format, a.String()) },
+			ArgsF: func(a Addable) []any {
+				return append([]any{raw(relation + ".From"), raw(relation + ".Condition")}, a.Args()...)
+			},
+		}
+	}
+}
@@ -2,6 +2,7 @@ package internal
 
 import (
 	"go/ast"
+	"strings"
 )
 
 var packageIdentGenerators = map[string]map[string]typedGenerator[*ast.SelectorExpr]{}
@@ -19,7 +20,7 @@ func addPackageIdentGenerator(packageName, identName string, generator typedGene
 func addPackageIdentGenerators() {
 	addGenerator(func(p *whereBodyParser, s *ast.SelectorExpr, args map[string]int) Addable {
 		ident, ok := s.X.(*ast.Ident)
-		if !ok || ident.Obj != nil {
+		if !ok || !p.c.isPackageName(ident) {
 			return nil
 		}
 
@@ -37,20 +38,6 @@ func addPackageIdentGenerators() {
 	})
 }
 
-func timeIdentsGenerator(_ *whereBodyParser, s *ast.SelectorExpr, _ map[string]int) Addable {
-	var duration string
-	switch s.Sel.Name {
-	case "Microsecond":
-		duration = "1 microsecond"
-	case "Millisecond":
-		duration = "1 millisecond"
-	case "Second":
-		duration = "1 second"
-	case "Minute":
-		duration = "1 minute"
-	case "Hour":
-		duration = "1 hour"
-	}
-
-	return NewSimple("INTERVAL '" + duration + "'")
+func timeIdentsGenerator(p *whereBodyParser, s *ast.SelectorExpr, _ map[string]int) Addable {
+	return interval{dialect: p.dialect, unit: strings.ToLower(s.Sel.Name)}
 }
@@ -0,0 +1,281 @@
+// Code generated by goquery; DO NOT EDIT.
+
+package internal_test
+
+import (
+	"github.com/ffenix113/goquery"
+	models "github.com/ffenix113/goquery/internal/testdata/models"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	goquery.RegisterPackagePath("github.com/ffenix113/goquery/internal")
+	goquery.AddToGlobalEntity[*Customer](
+		goquery.Calls{
+			Where: map[goquery.Caller]goquery.QueryFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 86}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("EXISTS (SELECT 1 FROM ? WHERE ? AND (? > ?))",
+						[]any{
+							goquery.Relation(helper, "Orders").From,
+							goquery.Relation(helper, "Orders").Condition,
+							goquery.Column(goquery.Relation(helper, "Orders").Helper, "Total"),
+							100}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 96}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where(goquery.DialectQuery(query, "NOT EXISTS (SELECT 1 FROM ? WHERE ? AND (not (? = ?) OR (? = ?) IS NULL))", map[string]string{
+						"mssql": "NOT EXISTS (SELECT 1 FROM ? WHERE ? AND (CASE WHEN ? = ? THEN 0 ELSE 1 END = 1))",
+					}),
+						goquery.DialectArgs(query, []any{
+							goquery.Relation(helper, "Orders").From,
+							goquery.Relation(helper, "Orders").Condition,
+							goquery.Column(goquery.Relation(helper, "Orders").Helper, "Paid"),
+							true,
+							goquery.Column(goquery.Relation(helper, "Orders").Helper, "Paid"),
+							true}, map[string][]any{
+							"mssql": {
+								goquery.Relation(helper, "Orders").From,
+								goquery.Relation(helper, "Orders").Condition,
+								goquery.Column(goquery.Relation(helper, "Orders").Helper, "Paid"),
+								true},
+						})...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 106}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("(SELECT count(*) FROM ? WHERE ? AND (? < ?)) >= ?",
+						[]any{
+							goquery.Relation(helper, "Orders").From,
+							goquery.Relation(helper, "Orders").Condition,
+							goquery.Column(goquery.Relation(helper, "Orders").Helper, "Total"),
+							goquery.Column(goquery.Qualified(helper), "Limit"),
+							2}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 117}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("EXISTS (SELECT 1 FROM ? WHERE ? AND (EXISTS (SELECT 1 FROM ? WHERE ? AND (? = ?))))",
+						[]any{
+							goquery.Relation(helper, "Orders").From,
+							goquery.Relation(helper, "Orders").Condition,
+							goquery.Relation(goquery.Relation(helper, "Orders").Helper, "Tags").From,
+							goquery.Relation(goquery.Relation(helper, "Orders").Helper, "Tags").Condition,
+							goquery.Column(goquery.Relation(goquery.Relation(helper, "Orders").Helper, "Tags").Helper, "Name"),
+							args[0]}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 132}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("EXISTS (SELECT 1 FROM ? WHERE ? AND (EXISTS (SELECT 1 FROM ? WHERE ? AND (? = ?))))",
+						[]any{
+							goquery.Relation(helper, "Orders").From,
+							goquery.Relation(helper, "Orders").Condition,
+							goquery.Relation(goquery.Relation(helper, "Orders").Helper, "Tags").From,
+							goquery.Relation(goquery.Relation(helper, "Orders").Helper, "Tags").Condition,
+							goquery.Column(goquery.Relation(goquery.Relation(helper, "Orders").Helper, "Tags").Helper, "Name"),
+							args[0]}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 146}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("EXISTS (SELECT 1 FROM ? WHERE ? AND (not (? = ?) AND (? < ?)))",
+						[]any{
+							goquery.Relation(helper, "Orders").From,
+							goquery.Relation(helper, "Orders").Condition,
+							goquery.Column(goquery.Relation(helper, "Orders").Helper, "Paid"),
+							true,
+							goquery.Column(goquery.Relation(helper, "Orders").Helper, "Total"),
+							goquery.Column(goquery.Qualified(helper), "Limit")}...)
+				},
+			},
+			OrderBy: map[goquery.Caller]goquery.ExprFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 179}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "ID")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 323}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "ID")}
+				},
+			},
+			Include: map[goquery.Caller]goquery.QueryFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 286}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					goquery.IncludeRelation(helper, query, "Orders", nil, args...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 294}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					goquery.IncludeRelation(helper, query, "Orders", func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+						query.Where("? > ?",
+							[]any{
+								goquery.Column(helper, "Total"),
+								args[0]}...)
+					}, args...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 304}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					goquery.IncludeRelation(helper, query, "Orders", func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+						query.Where("? < ?",
+							[]any{
+								goquery.Column(helper, "Total"),
+								args[0]}...)
+					}, args...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 313}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					goquery.IncludeRelation(helper, query, "Orders.Tags", nil, args...)
+				},
+			},
+			Captures: map[string]map[goquery.Caller]goquery.CaptureFunc{
+				"Include": {
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 304}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 int
+						})(goquery.Closure(fn))
+
+						return []any{closure.V0}
+					},
+				},
+				"Where": {
+					goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 132}: func(fn any) []any {
+						closure := (*struct {
+							F  uintptr
+							V0 string
+						})(goquery.Closure(fn))
+
+						return []any{closure.V0}
+					},
+				},
+			},
+		},
+	)
+
+	goquery.AddToGlobalEntity[*Order](
+		goquery.Calls{
+			Where: map[goquery.Caller]goquery.QueryFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 207}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ? AND ? > ?",
+						[]any{
+							goquery.Column(goquery.Join(helper, query, "Customer"), "Country"),
+							"DE",
+							goquery.Column(helper, "Total"),
+							100}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 216}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(goquery.Join(helper, query, "Customer"), "Country"),
+							"DE"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 217}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? > ?",
+						[]any{
+							goquery.Column(goquery.Join(helper, query, "Customer"), "Limit"),
+							100}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 228}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("(not (?) AND ? > ?) OR (? = ?) AND (? >= ?)",
+						[]any{
+							goquery.Column(helper, "Paid"),
+							goquery.Column(helper, "Total"),
+							args[0],
+							goquery.Column(goquery.Join(helper, query, "Customer"), "Country"),
+							"DE",
+							goquery.Column(helper, "Total"),
+							100}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 239}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("((? * ?) * ?) > ?",
+						[]any{
+							goquery.Column(helper, "Total"),
+							2,
+							2,
+							100}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 240}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("((? = ?) OR ((((? >= ?)) OR (? < ?))))",
+						[]any{
+							goquery.Column(helper, "Paid"),
+							true,
+							goquery.Column(helper, "Total"),
+							100,
+							goquery.Column(helper, "Total"),
+							30}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 350}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(goquery.Join(helper, query, "Customer"), "Country"),
+							"DE"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 374}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ? AND not (?)",
+						[]any{
+							goquery.Column(goquery.Join(helper, query, "Customer"), "Country"),
+							"DE",
+							goquery.Column(helper, "Paid")}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 383}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(goquery.Join(helper, query, "Customer"), "Name"),
+							"jane"}...)
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 388}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where("? = ?",
+						[]any{
+							goquery.Column(helper, "Paid"),
+							true}...)
+				},
+			},
+			OrderBy: map[goquery.Caller]goquery.ExprFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 218}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(goquery.Join(helper, query.QueryBuilder(), "Customer"), "Name")}
+				},
+
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 351}: func(helper goquery.Helper, query *bun.SelectQuery, args ...any) (string, []any) {
+					return "?", []any{
+						goquery.Column(helper, "Total")}
+				},
+			},
+			Include: map[goquery.Caller]goquery.QueryFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 349}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					goquery.IncludeRelation(helper, query, "Customer", nil, args...)
+				},
+			},
+			Update: map[goquery.Caller]goquery.UpdateFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 375}: func(helper goquery.Helper, query *bun.UpdateQuery, args ...any) {
+					query.Set("? = ?",
+						[]any{
+							goquery.Column(helper, "Paid"),
+							true}...)
+				},
+			},
+		},
+	)
+
+	goquery.AddToGlobalEntity[*models.Invoice](
+		goquery.Calls{
+			Where: map[goquery.Caller]goquery.QueryFunc{
+				goquery.Caller{File: "github.com/ffenix113/goquery/internal/relations_test.go", Line: 454}: func(helper goquery.Helper, query bun.QueryBuilder, args ...any) {
+					query.Where(goquery.DialectQuery(query, "(? < NOW() AND not (?)) OR (? >= ?)", map[string]string{
+						"mssql":  "(? < SYSDATETIME() AND not (?)) OR (? >= ?)",
+						"sqlite": "(? < (rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', 'now'), '0'), '.') || '+00:00') AND not (?)) OR (? >= ?)",
+					}),
+						[]any{
+							goquery.Column(helper, "DueAt"),
+							goquery.Column(helper, "Paid"),
+							goquery.Column(helper, "Total"),
+							100}...)
+				},
+			},
+		},
+	)
+}
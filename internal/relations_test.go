@@ -0,0 +1,469 @@
+//go:generate go run ../cmd/goquery/main.go
+
+package internal_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+
+	"github.com/ffenix113/goquery"
+	"github.com/ffenix113/goquery/goquerytest"
+	"github.com/ffenix113/goquery/internal/testdata/models"
+)
+
+type Customer struct {
+	ID      int64 `bun:",pk,autoincrement"`
+	Name    string
+	Country string
+	Limit   int
+	Orders  []*Order `bun:"rel:has-many,join:id=customer_id"`
+}
+
+type Order struct {
+	ID         int64 `bun:",pk,autoincrement"`
+	CustomerID int64
+	Customer   *Customer `bun:"rel:belongs-to,join:customer_id=id"`
+	Total      int
+	Paid       bool
+	Tags       []*Tag `bun:"m2m:order_to_tags,join:Order=Tag"`
+}
+
+type Tag struct {
+	ID   int64 `bun:",pk,autoincrement"`
+	Name string
+}
+
+// IsUnpaidOver is inlined into queries it is called in.
+func (o *Order) IsUnpaidOver(limit int) bool {
+	return !o.Paid && o.Total > limit
+}
+
+func (c *Customer) IsLocal() bool {
+	return c.Country == "DE"
+}
+
+func isLarge(total int) bool {
+	return total >= 100
+}
+
+func twice(total int) int {
+	return total * 2
+}
+
+func either(a, b bool) bool {
+	return a || b
+}
+
+type OrderToTag struct {
+	OrderID int64  `bun:",pk"`
+	Order   *Order `bun:"rel:belongs-to,join:order_id=id"`
+	TagID   int64  `bun:",pk"`
+	Tag     *Tag   `bun:"rel:belongs-to,join:tag_id=id"`
+}
+
+func TestRelationPredicates(t *testing.T) {
+	ctx := context.Background()
+	db := getRelationsDB(t)
+
+	factory := goquery.NewFactory[*Customer](db)
+
+	tests := []struct {
+		name   string
+		f      func(q goquery.Queryable[*Customer])
+		result string
+		names  []string
+	}{
+		{
+			name: "any",
+			f: func(q goquery.Queryable[*Customer]) {
+				q.Where(func(c *Customer) bool {
+					return goquery.Any(c.Orders, func(o *Order) bool { return o.Total > 100 })
+				})
+			},
+			result: `WHERE (EXISTS (SELECT 1 FROM "orders" AS "order" WHERE "order"."customer_id" = "customer"."id" AND ("order"."total" > 100)))`,
+			names:  []string{"john"},
+		},
+		{
+			name: "all",
+			f: func(q goquery.Queryable[*Customer]) {
+				q.Where(func(c *Customer) bool {
+					return goquery.All(c.Orders, func(o *Order) bool { return o.Paid })
+				})
+			},
+			result: `WHERE (NOT EXISTS (SELECT 1 FROM "orders" AS "order" WHERE "order"."customer_id" = "customer"."id" AND (not ("order"."paid" = TRUE) OR ("order"."paid" = TRUE) IS NULL)))`,
+			names:  []string{"jane", "jack"},
+		},
+		{
+			name: "count with outer column",
+			f: func(q goquery.Queryable[*Customer]) {
+				q.Where(func(c *Customer) bool {
+					return goquery.Count(c.Orders, func(o *Order) bool { return o.Total < c.Limit }) >= 2
+				})
+			},
+			result: `WHERE ((SELECT count(*) FROM "orders" AS "order" WHERE "order"."customer_id" = "customer"."id" AND ("order"."total" < "customer"."limit")) >= 2)`,
+			names:  []string{"john"},
+		},
+		{
+			name: "many to many",
+			f: func(q goquery.Queryable[*Customer]) {
+				tag := "gift"
+				q.Where(func(c *Customer) bool {
+					return goquery.Any(c.Orders, func(o *Order) bool {
+						return goquery.Any(o.Tags, func(t *Tag) bool { return t.Name == tag })
+					})
+				}, tag)
+			},
+			result: `WHERE (EXISTS (SELECT 1 FROM "orders" AS "order" WHERE "order"."customer_id" = "customer"."id" AND ` +
+				`(EXISTS (SELECT 1 FROM "tags" AS "tag" JOIN "order_to_tags" AS "order_to_tag" ON "order_to_tag"."tag_id" = "tag"."id" ` +
+				`WHERE "order_to_tag"."order_id" = "order"."id" AND ("tag"."name" = 'gift')))))`,
+			names: []string{"jane"},
+		},
+		{
+			name: "captured in subquery",
+			f: func(q goquery.Queryable[*Customer]) {
+				tag := "gift"
+				q.Where(func(c *Customer) bool {
+					return goquery.Any(c.Orders, func(o *Order) bool {
+						return goquery.Any(o.Tags, func(t *Tag) bool { return t.Name == tag })
+					})
+				})
+			},
+			result: `WHERE (EXISTS (SELECT 1 FROM "orders" AS "order" WHERE "order"."customer_id" = "customer"."id" AND ` +
+				`(EXISTS (SELECT 1 FROM "tags" AS "tag" JOIN "order_to_tags" AS "order_to_tag" ON "order_to_tag"."tag_id" = "tag"."id" ` +
+				`WHERE "order_to_tag"."order_id" = "order"."id" AND ("tag"."name" = 'gift')))))`,
+			names: []string{"jane"},
+		},
+		{
+			name: "local variables in subquery",
+			f: func(q goquery.Queryable[*Customer]) {
+				q.Where(func(c *Customer) bool {
+					limit := c.Limit
+					return goquery.Any(c.Orders, func(o *Order) bool {
+						if o.Paid {
+							return false
+						}
+
+						return o.Total < limit
+					})
+				})
+			},
+			result: `WHERE (EXISTS (SELECT 1 FROM "orders" AS "order" WHERE "order"."customer_id" = "customer"."id" AND ` +
+				`(not ("order"."paid" = TRUE) AND ("order"."total" < "customer"."limit"))))`,
+			names: []string{"john"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			q := factory.New(db.NewSelect().Model((*Customer)(nil)))
+			test.f(q)
+
+			var wrapper goquerytest.Conn
+			_, err := q.Query().Conn(&wrapper).Exec(ctx)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.result, wrapper.Query[len(`SELECT "customer"."id", "customer"."name", "customer"."country", "customer"."limit" FROM "customers" AS "customer" `):])
+
+			q = factory.New()
+			test.f(q)
+
+			customers, err := q.OrderBy(func(c *Customer) any { return c.ID }).ToSlice(ctx)
+			require.NoError(t, err)
+
+			names := make([]string, 0, len(customers))
+			for _, customer := range customers {
+				names = append(names, customer.Name)
+			}
+
+			assert.Equal(t, test.names, names)
+		})
+	}
+}
+
+func TestRelationNavigation(t *testing.T) {
+	ctx := context.Background()
+	db := getRelationsDB(t)
+
+	factory := goquery.NewFactory[*Order](db)
+
+	tests := []struct {
+		name   string
+		f      func(q goquery.Queryable[*Order]) goquery.Queryable[*Order]
+		result string
+		totals []int
+	}{
+		{
+			name: "belongs to",
+			f: func(q goquery.Queryable[*Order]) goquery.Queryable[*Order] {
+				return q.Where(func(o *Order) bool { return o.Customer.Country == "DE" && o.Total > 100 })
+			},
+			result: `LEFT JOIN "customers" AS "order__customer" ON "order__customer"."id" = "order"."customer_id" ` +
+				`WHERE ("order__customer"."country" = 'DE' AND "order"."total" > 100)`,
+			totals: []int{150},
+		},
+		{
+			name: "joined once",
+			f: func(q goquery.Queryable[*Order]) goquery.Queryable[*Order] {
+				return q.Where(func(o *Order) bool { return o.Customer.Country == "DE" }).
+					Where(func(o *Order) bool { return o.Customer.Limit > 100 }).
+					OrderByDescending(func(o *Order) any { return o.Customer.Name })
+			},
+			result: `LEFT JOIN "customers" AS "order__customer" ON "order__customer"."id" = "order"."customer_id" ` +
+				`WHERE ("order__customer"."country" = 'DE') AND ("order__customer"."limit" > 100) ORDER BY "order__customer"."name" DESC`,
+			totals: []int{150, 50},
+		},
+		{
+			name: "inlined functions",
+			f: func(q goquery.Queryable[*Order]) goquery.Queryable[*Order] {
+				minTotal := 10
+				return q.Where(func(o *Order) bool {
+					return o.IsUnpaidOver(minTotal) || o.Customer.IsLocal() && isLarge(o.Total)
+				}, minTotal)
+			},
+			result: `LEFT JOIN "customers" AS "order__customer" ON "order__customer"."id" = "order"."customer_id" ` +
+				`WHERE ((not ("order"."paid") AND "order"."total" > 10) OR ("order__customer"."country" = 'DE') AND ("order"."total" >= 100))`,
+			totals: []int{150, 50},
+		},
+		{
+			name: "nested inlined calls",
+			f: func(q goquery.Queryable[*Order]) goquery.Queryable[*Order] {
+				return q.Where(func(o *Order) bool { return twice(twice(o.Total)) > 100 }).
+					Where(func(o *Order) bool { return either(o.Paid, either(isLarge(o.Total), o.Total < 30)) })
+			},
+			result: `WHERE ((("total" * 2) * 2) > 100) AND ((("paid" = TRUE) OR (((("total" >= 100)) OR ("total" < 30)))))`,
+			totals: []int{150},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			q := test.f(factory.New(db.NewSelect().Model((*Order)(nil))))
+
+			var wrapper goquerytest.Conn
+			_, err := q.Query().Conn(&wrapper).Exec(ctx)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.result, wrapper.Query[len(`SELECT "order"."id", "order"."customer_id", "order"."total", "order"."paid" FROM "orders" AS "order" `):])
+
+			orders, err := test.f(factory.New()).ToSlice(ctx)
+			require.NoError(t, err)
+
+			totals := make([]int, 0, len(orders))
+			for _, order := range orders {
+				totals = append(totals, order.Total)
+			}
+
+			assert.ElementsMatch(t, test.totals, totals)
+		})
+	}
+}
+
+func TestInclude(t *testing.T) {
+	ctx := context.Background()
+	db := getRelationsDB(t)
+
+	factory := goquery.NewFactory[*Customer](db)
+
+	tests := []struct {
+		name      string
+		f         func(q goquery.Queryable[*Customer]) goquery.Queryable[*Customer]
+		customers []string
+	}{
+		{
+			name: "include",
+			f: func(q goquery.Queryable[*Customer]) goquery.Queryable[*Customer] {
+				return q.Include(func(c *Customer) any { return c.Orders })
+			},
+			customers: []string{"john: 150[] 50[]", "jane: 20[]", "jack:"},
+		},
+		{
+			name: "include where",
+			f: func(q goquery.Queryable[*Customer]) goquery.Queryable[*Customer] {
+				minTotal := 100
+				return goquery.IncludeWhere(q, func(c *Customer) []*Order { return c.Orders }, func(o *Order) bool {
+					return o.Total > minTotal
+				}, minTotal)
+			},
+			customers: []string{"john: 150[]", "jane:", "jack:"},
+		},
+		{
+			name: "include where captured",
+			f: func(q goquery.Queryable[*Customer]) goquery.Queryable[*Customer] {
+				maxTotal := 100
+				return goquery.IncludeWhere(q, func(c *Customer) []*Order { return c.Orders }, func(o *Order) bool {
+					return o.Total < maxTotal
+				})
+			},
+			customers: []string{"john: 50[]", "jane: 20[]", "jack:"},
+		},
+		{
+			name: "then include",
+			f: func(q goquery.Queryable[*Customer]) goquery.Queryable[*Customer] {
+				return goquery.ThenInclude(q, func(c *Customer) []*Order { return c.Orders }, func(o *Order) any { return o.Tags })
+			},
+			customers: []string{"john: 150[express] 50[]", "jane: 20[gift]", "jack:"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+
+		t.Run(test.name, func(t *testing.T) {
+			customers, err := test.f(factory.New()).OrderBy(func(c *Customer) any { return c.ID }).ToSlice(ctx)
+			require.NoError(t, err)
+
+			descriptions := make([]string, 0, len(customers))
+			for _, customer := range customers {
+				var buf strings.Builder
+				buf.WriteString(customer.Name + ":")
+
+				for _, order := range customer.Orders {
+					tags := make([]string, 0, len(order.Tags))
+					for _, tag := range order.Tags {
+						tags = append(tags, tag.Name)
+					}
+
+					fmt.Fprintf(&buf, " %d[%s]", order.Total, strings.Join(tags, ","))
+				}
+
+				descriptions = append(descriptions, buf.String())
+			}
+
+			assert.Equal(t, test.customers, descriptions)
+		})
+	}
+
+	t.Run("belongs to with navigation", func(t *testing.T) {
+		orders, err := goquery.NewFactory[*Order](db).New().
+			Include(func(o *Order) any { return o.Customer }).
+			Where(func(o *Order) bool { return o.Customer.Country == "DE" }).
+			OrderBy(func(o *Order) any { return o.Total }).
+			ToSlice(ctx)
+		require.NoError(t, err)
+
+		require.Len(t, orders, 2)
+		for i, total := range []int{50, 150} {
+			assert.Equal(t, total, orders[i].Total)
+			require.NotNil(t, orders[i].Customer)
+			assert.Equal(t, "john", orders[i].Customer.Name)
+		}
+	})
+}
+
+func TestRelationsInUpdateAndDelete(t *testing.T) {
+	ctx := context.Background()
+	db := getRelationsDB(t)
+
+	var hook queryHook
+	db.AddQueryHook(&hook)
+
+	orders := goquery.NewFactory[*Order](db)
+
+	updated, err := orders.New().
+		Where(func(o *Order) bool { return o.Customer.Country == "DE" && !o.Paid }).
+		Update(ctx, func(o *Order) { o.Paid = true })
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, updated)
+	assert.Equal(t, `UPDATE "orders" AS "order" SET "paid" = TRUE WHERE (("id") IN (`+
+		`SELECT "order"."id" FROM "orders" AS "order" LEFT JOIN "customers" AS "order__customer" ON "order__customer"."id" = "order"."customer_id" `+
+		`WHERE ("order__customer"."country" = 'DE' AND not ("order"."paid"))))`, hook.query)
+
+	deleted, err := orders.New().
+		Where(func(o *Order) bool { return o.Customer.Name == "jane" }).
+		Delete(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, deleted)
+
+	paid, err := orders.New().Where(func(o *Order) bool { return o.Paid }).Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, paid)
+}
+
+func getRelationsDB(t testing.TB) *bun.DB {
+	ctx := context.Background()
+	db := getDB(t)
+
+	db.RegisterModel((*OrderToTag)(nil))
+
+	models := []any{(*Customer)(nil), (*Order)(nil), (*Tag)(nil), (*OrderToTag)(nil)}
+	for _, model := range models {
+		model := model
+
+		_, err := db.NewCreateTable().Model(model).Exec(ctx)
+		require.NoError(t, err)
+
+		t.Cleanup(func() {
+			_, err := db.NewDropTable().Model(model).Exec(ctx)
+			require.NoError(t, err)
+		})
+	}
+
+	customers := []*Customer{
+		{Name: "john", Country: "DE", Limit: 1000},
+		{Name: "jane", Country: "US", Limit: 10},
+		{Name: "jack", Country: "DE"},
+	}
+	orders := []*Order{
+		{CustomerID: 1, Total: 150, Paid: true},
+		{CustomerID: 1, Total: 50},
+		{CustomerID: 2, Total: 20, Paid: true},
+	}
+	tags := []*Tag{{Name: "gift"}, {Name: "express"}}
+	orderTags := []*OrderToTag{{OrderID: 1, TagID: 2}, {OrderID: 3, TagID: 1}}
+
+	for _, rows := range []any{&customers, &orders, &tags, &orderTags} {
+		_, err := db.NewInsert().Model(rows).Exec(ctx)
+		require.NoError(t, err)
+	}
+
+	return db
+}
+
+func TestInlineImported(t *testing.T) {
+	ctx := context.Background()
+	db := getDB(t)
+
+	_, err := db.NewCreateTable().Model((*models.Invoice)(nil)).Exec(ctx)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_, err := db.NewDropTable().Model((*models.Invoice)(nil)).Exec(ctx)
+		require.NoError(t, err)
+	})
+
+	invoices := []*models.Invoice{
+		{DueAt: time.Now().Add(-time.Hour), Total: 10},
+		{DueAt: time.Now().Add(-time.Hour), Paid: true, Total: 20},
+		{DueAt: time.Now().Add(time.Hour), Total: 150},
+	}
+	_, err = db.NewInsert().Model(&invoices).Exec(ctx)
+	require.NoError(t, err)
+
+	// Methods and functions of the other package are inlined from it.
+	q := goquery.NewFactory[*models.Invoice](db).New().Where(func(i *models.Invoice) bool { return i.IsOverdue() || models.IsLarge(i.Total) })
+
+	sql, _, err := goquerytest.SQL(q)
+	require.NoError(t, err)
+	assert.Equal(t, `SELECT * WHERE (("due_at" < (rtrim(rtrim(strftime('%Y-%m-%d %H:%M:%f', 'now'), '0'), '.') || '+00:00') AND not ("paid")) OR ("total" >= 100))`, sql)
+
+	res, err := q.ToSlice(ctx)
+	require.NoError(t, err)
+
+	totals := make([]int, 0, len(res))
+	for _, invoice := range res {
+		totals = append(totals, invoice.Total)
+	}
+
+	assert.ElementsMatch(t, []int{10, 150}, totals)
+}
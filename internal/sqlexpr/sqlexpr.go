@@ -0,0 +1,151 @@
+// Package sqlexpr contains the tree of SQL expressions.
+//
+// The tree is built by the generator from lambdas, and by
+// goquery at runtime for filters that depend on the values,
+// like keyset pagination. It must not have dependencies, so
+// programs using goquery do not depend on the generator.
+package sqlexpr
+
+import (
+	"strings"
+)
+
+// Param is the placeholder of the argument.
+const Param = "?"
+
+type Addable interface {
+	String() string
+	Args() []any
+}
+
+type Simple struct {
+	StringVal string
+	Arg       []any
+}
+
+func NewSimple(val string, args ...any) *Simple {
+	return &Simple{
+		StringVal: val,
+		Arg:       args,
+	}
+}
+
+func (s Simple) String() string {
+	return s.StringVal
+}
+
+func (s Simple) Args() []any {
+	return s.Arg
+}
+
+// List is a comma separated list of addables.
+type List []Addable
+
+func (l List) String() string {
+	var buf strings.Builder
+
+	for i, addable := range l {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(addable.String())
+	}
+
+	return buf.String()
+}
+
+func (l List) Args() []any {
+	var args []any
+
+	for _, addable := range l {
+		args = append(args, addable.Args()...)
+	}
+
+	return args
+}
+
+type Parens struct {
+	Addable
+}
+
+func (p Parens) String() string {
+	return "(" + p.Addable.String() + ")"
+}
+
+// And is a conjunction of the conditions.
+type And []Addable
+
+func NewAnd(conditions ...Addable) And {
+	return conditions
+}
+
+func (a And) String() string {
+	var buf strings.Builder
+
+	for _, condition := range a {
+		if buf.Len() > 0 {
+			buf.WriteString(" AND ")
+		}
+		buf.WriteString(condition.String())
+	}
+
+	return buf.String()
+}
+
+func (a And) Args() []any {
+	var args []any
+
+	for _, condition := range a {
+		args = append(args, condition.Args()...)
+	}
+
+	return args
+}
+
+// Or is a disjunction of two conditions.
+type Or struct {
+	Left, Right Addable
+}
+
+func NewOr(left, right Addable) Or {
+	return Or{Left: left, Right: right}
+}
+
+func (o Or) String() string {
+	return o.Left.String() + " OR " + o.Right.String()
+}
+
+func (o Or) Args() []any {
+	return append(append([]any(nil), o.Left.Args()...), o.Right.Args()...)
+}
+
+// Binary is an operation on two operands, like `a > b`.
+type Binary struct {
+	Op    string
+	Left  Addable
+	Right Addable
+}
+
+func NewBinary(left Addable, op string, right Addable) *Binary {
+	return &Binary{
+		Left:  left,
+		Right: right,
+		Op:    op,
+	}
+}
+
+func (b *Binary) String() string {
+	var buf strings.Builder
+
+	buf.WriteString(b.Left.String())
+	buf.WriteByte(' ')
+	buf.WriteString(b.Op)
+	buf.WriteByte(' ')
+	buf.WriteString(b.Right.String())
+
+	return buf.String()
+}
+
+func (b *Binary) Args() []any {
+	return append(append([]any(nil), b.Left.Args()...), b.Right.Args()...)
+}
@@ -0,0 +1,14 @@
+package generate
+
+import (
+	"github.com/ffenix113/goquery"
+)
+
+type Order struct {
+	ID    int64
+	Total int
+}
+
+func Large(q goquery.Queryable[*Order]) goquery.Queryable[*Order] {
+	return q.Where(func(o *Order) bool { return o.Total > 100 })
+}
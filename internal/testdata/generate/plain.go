@@ -0,0 +1,4 @@
+package generate
+
+// Answer has no queries, so no file is generated for it.
+const Answer = 42
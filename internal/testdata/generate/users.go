@@ -0,0 +1,14 @@
+package generate
+
+import (
+	"github.com/ffenix113/goquery"
+)
+
+type User struct {
+	ID   int64
+	Name string
+}
+
+func ByName(q goquery.Queryable[*User], name string) goquery.Queryable[*User] {
+	return q.Where(func(u *User) bool { return u.Name == name }, name)
+}
@@ -0,0 +1,62 @@
+package invalid
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ffenix113/goquery"
+)
+
+type User struct {
+	ID   int64
+	Name string
+	Rank int64
+}
+
+// Range filters are not chained, so order of their evaluation is unknown.
+func Range(from, to goquery.Queryable[*User]) []goquery.Queryable[*User] {
+	return []goquery.Queryable[*User]{from.Where(func(u *User) bool { return u.ID > 1 }), to.Where(func(u *User) bool { return u.ID < 5 })}
+}
+
+// Types declared in functions cannot be named in generated code.
+func ByName(q goquery.Queryable[*User], name string) goquery.Queryable[*User] {
+	type filter struct{ name string }
+	f := filter{name: name}
+
+	return q.Where(func(u *User) bool { return u.Name == f.name })
+}
+
+func ByID(q goquery.Queryable[*User], id int64) goquery.Queryable[*User] {
+	return q.Where(func(u *User) bool { return u.Name == strconv.FormatInt(id, 10) }, id)
+}
+
+// Variables of generic functions are not taken from closures.
+func ByFirstID[T any](q goquery.Queryable[*User], ids []int64) goquery.Queryable[*User] {
+	return q.Where(func(u *User) bool { return u.ID == ids[0] }, ids[1])
+}
+
+func ByShortID(q goquery.Queryable[*User]) goquery.Queryable[*User] {
+	return q.Where(func(u *User) bool { return int8(u.ID) == 1 })
+}
+
+func Promote(ctx context.Context, q goquery.Queryable[*User]) (int64, error) {
+	return q.Update(ctx, func(u *User) {
+		u.ID++
+		u.Rank = u.ID + 1
+	})
+}
+
+func ByOddID(q goquery.Queryable[*User]) goquery.Queryable[*User] {
+	return q.Where(func(u *User) bool { return u.ID%2 == 1 })
+}
+
+const strict = false
+
+// Assignments in dead code are removed by the compiler.
+func ByRank(q goquery.Queryable[*User], rank int64) goquery.Queryable[*User] {
+	if strict {
+		rank++
+	}
+
+	return q.Where(func(u *User) bool { return u.Rank >= rank })
+}
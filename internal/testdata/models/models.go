@@ -0,0 +1,23 @@
+// Package models declares entities outside of the package
+// of the queries, so their methods are inlined from it.
+package models
+
+import "time"
+
+const largeTotal = 100
+
+type Invoice struct {
+	ID    int64 `bun:",pk,autoincrement"`
+	DueAt time.Time
+	Paid  bool
+	Total int
+}
+
+// IsOverdue is inlined into queries of other packages.
+func (i *Invoice) IsOverdue() bool {
+	return i.DueAt.Before(time.Now()) && !i.Paid
+}
+
+func IsLarge(total int) bool {
+	return total >= largeTotal
+}
@@ -2,13 +2,31 @@ package internal
 
 import (
 	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type TimePackage struct{}
 type TimeType struct{}
 
 func (TimePackage) now(p *whereBodyParser, s *ast.CallExpr, args map[string]int) Addable {
-	return NewSimple("NOW()")
+	return NewSimple(now(p.dialect))
+}
+
+// add adds duration to the time. Durations built from
+// time package constants are added with date functions
+// of the dialect, as not all dialects support intervals.
+func (TimeType) add(p *whereBodyParser, s *ast.CallExpr, args map[string]int) Addable {
+	t := p.exprToAddable(s.Fun.(*ast.SelectorExpr).X, args)
+	duration := p.exprToAddable(s.Args[0], args)
+
+	if interval, ok := duration.(interval); ok {
+		return dateAdd{time: t, interval: interval}
+	}
+
+	return newBinary(t, tokenToOperation(token.ADD), duration)
 }
 
 func (TimeType) binary(op string) typedGenerator[*ast.CallExpr] {
@@ -20,3 +38,150 @@ func (TimeType) binary(op string) typedGenerator[*ast.CallExpr] {
 		)
 	}
 }
+
+type GroupType struct{}
+
+func (GroupType) key(p *whereBodyParser, s *ast.SelectorExpr, args map[string]int) Addable {
+	return NewSimple(param, raw("goquery.GroupKey(helper)"))
+}
+
+func (GroupType) count(p *whereBodyParser, s *ast.CallExpr, args map[string]int) Addable {
+	return NewSimple("count(*)")
+}
+
+func (GroupType) aggregate(funcName string) typedGenerator[*ast.CallExpr] {
+	return func(p *whereBodyParser, s *ast.CallExpr, args map[string]int) Addable {
+		return Wrapper{
+			Addable: p.parseLambda(s.Args[0], args),
+			StringF: func(a Addable) string { return funcName + "(" + a.String() + ")" },
+		}
+	}
+}
+
+// interval is a duration of the amount of units.
+type interval struct {
+	dialect string
+	// amount of units, nil for a single unit.
+	amount Addable
+	unit   string
+}
+
+// times returns interval multiplied by the amount.
+func (i interval) times(amount Addable) interval {
+	if i.amount != nil {
+		amount = newBinary(amount, tokenToOperation(token.MUL), i.amount)
+	}
+
+	i.amount = amount
+
+	return i
+}
+
+// unitDurations are durations of the interval units.
+var unitDurations = map[string]time.Duration{
+	"microsecond": time.Microsecond,
+	"millisecond": time.Millisecond,
+	"second":      time.Second,
+	"minute":      time.Minute,
+	"hour":        time.Hour,
+}
+
+// String returns the interval as a number of nanoseconds,
+// the same as time.Duration values are stored by bun.
+// Intervals added to time are rendered by dateAdd.
+func (i interval) String() string {
+	nanoseconds := strconv.FormatInt(int64(unitDurations[i.unit]), 10)
+	if i.amount == nil {
+		return nanoseconds
+	}
+
+	return i.amount.String() + " * " + nanoseconds
+}
+
+// sqlInterval returns the interval with INTERVAL
+// syntax, which is supported by PostgreSQL and MySQL.
+func (i interval) sqlInterval() string {
+	if i.dialect == DialectMySQL {
+		amount, unit := i.mysqlAmount()
+
+		return "INTERVAL " + amount + " " + unit
+	}
+
+	str := "INTERVAL '1 " + i.unit + "'"
+	if i.amount != nil {
+		str = i.amount.String() + " * " + str
+	}
+
+	return str
+}
+
+func (i interval) Args() []any {
+	if i.amount == nil {
+		return nil
+	}
+
+	return i.amount.Args()
+}
+
+func (i interval) amountString() string {
+	if i.amount == nil {
+		return "1"
+	}
+
+	return i.amount.String()
+}
+
+// mysqlAmount returns amount and unit of the interval
+// in MySQL, which does not have millisecond unit.
+func (i interval) mysqlAmount() (string, string) {
+	if i.unit == "millisecond" {
+		return "(" + i.amountString() + ") * 1000", "MICROSECOND"
+	}
+
+	return i.amountString(), strings.ToUpper(i.unit)
+}
+
+// sqliteModifier returns SQLite date function modifier
+// that adds the interval, i.e. '+1 hour'.
+func (i interval) sqliteModifier() string {
+	unit, divisor := i.unit, ""
+	switch i.unit {
+	case "millisecond":
+		unit, divisor = "second", " / 1000.0"
+	case "microsecond":
+		unit, divisor = "second", " / 1000000.0"
+	}
+
+	if i.amount == nil && divisor == "" {
+		return "'+1 " + unit + "'"
+	}
+
+	return concat(DialectSQLite, "("+i.amountString()+divisor+")", "' "+unit+"s'")
+}
+
+// dateAdd is a time with interval added to it.
+type dateAdd struct {
+	time     Addable
+	interval interval
+}
+
+func (d dateAdd) String() string {
+	switch d.interval.dialect {
+	case DialectSQLite:
+		return sqliteTime(d.time.String() + ", " + d.interval.sqliteModifier())
+	case DialectMySQL:
+		return "DATE_ADD(" + d.time.String() + ", " + d.interval.sqlInterval() + ")"
+	case DialectMSSQL:
+		return "DATEADD(" + d.interval.unit + ", " + d.interval.amountString() + ", " + d.time.String() + ")"
+	default:
+		return d.time.String() + " + " + d.interval.sqlInterval()
+	}
+}
+
+func (d dateAdd) Args() []any {
+	if d.interval.dialect == DialectMSSQL {
+		return append(append([]any(nil), d.interval.Args()...), d.time.Args()...)
+	}
+
+	return append(append([]any(nil), d.time.Args()...), d.interval.Args()...)
+}
@@ -3,21 +3,21 @@ package internal
 import (
 	"fmt"
 	"go/ast"
+	"strings"
+
+	"github.com/ffenix113/goquery/internal/sqlexpr"
 )
 
 // raw is a string representation that will not
 // be quoted when added as an argument.
 type raw string
 
-type Addable interface {
-	String() string
-	Args() []any
-}
-
-type Simple struct {
-	StringVal string
-	Arg       []any
-}
+type (
+	Addable = sqlexpr.Addable
+	Simple  = sqlexpr.Simple
+	// List is a comma separated list of addables.
+	List = sqlexpr.List
+)
 
 func (r raw) String() string {
 	return string(r)
@@ -27,25 +27,28 @@ func (raw) Args() []any {
 	return nil
 }
 
-func NewColumn(name string) *Simple {
-	return NewSimple(param, raw("bun.Ident(helper.ColumnName(\""+name+"\"))"))
+// NewColumn returns column of the field
+// that is resolved with the provided helper expression.
+func NewColumn(helper, name string) *Simple {
+	return NewSimple(param, raw("goquery.Column("+helper+", \""+name+"\")"))
 }
 
 func NewSimple(val string, args ...any) *Simple {
-	return &Simple{
-		StringVal: val,
-		Arg:       args,
+	return sqlexpr.NewSimple(val, args...)
+}
+
+// newColumnAlias names the addable as a column
+// of the projection result struct field.
+func newColumnAlias(addable Addable, fieldName string) Addable {
+	return Wrapper{
+		Addable: addable,
+		StringF: func(a Addable) string { return a.String() + " AS ?" },
+		ArgsF: func(a Addable) []any {
+			return append(a.Args(), raw("bun.Ident(resultHelper.ColumnName(\""+fieldName+"\"))"))
+		},
 	}
 }
 
-func (s Simple) String() string {
-	return s.StringVal
-}
-
-func (s Simple) Args() []any {
-	return s.Arg
-}
-
 type Expression struct{ ast.Expr }
 
 func (e Expression) String() string {
@@ -63,20 +66,43 @@ func (e Expression) Args() []any {
 	return nil
 }
 
-type Parens struct {
+type Parens = sqlexpr.Parens
+
+type Not struct {
 	Addable
 }
 
-func (p Parens) String() string {
-	return "(" + p.Addable.String() + ")"
+func (n Not) String() string {
+	return "not (" + n.Addable.String() + ")"
 }
 
-type Not struct {
+// NotTrue is a negation of the condition that is
+// also satisfied if the condition is NULL, so it
+// selects exactly the rows that the condition does not.
+type NotTrue struct {
 	Addable
+	dialect string
 }
 
-func (n Not) String() string {
-	return "not (" + n.Addable.String() + ")"
+func (n NotTrue) String() string {
+	cond := n.Addable.String()
+
+	// Conditions are not values in MSSQL,
+	// so they cannot be compared with NULL.
+	if n.dialect == DialectMSSQL {
+		return "CASE WHEN " + cond + " THEN 0 ELSE 1 END = 1"
+	}
+
+	return "not (" + cond + ") OR (" + cond + ") IS NULL"
+}
+
+func (n NotTrue) Args() []any {
+	args := n.Addable.Args()
+	if n.dialect == DialectMSSQL {
+		return args
+	}
+
+	return append(append([]any(nil), args...), args...)
 }
 
 type Neg struct {
@@ -87,6 +113,43 @@ func (n Neg) String() string {
 	return "-" + n.Addable.String()
 }
 
+// Case is a conditional value, each of the
+// conditions is checked in order, and value
+// of the first satisfied one is used.
+type Case struct {
+	Whens []When
+	Else  Addable
+}
+
+// When is a condition of Case with its value.
+type When struct {
+	Cond, Then Addable
+}
+
+func (c Case) String() string {
+	var buf strings.Builder
+
+	buf.WriteString("CASE")
+	for _, when := range c.Whens {
+		buf.WriteString(" WHEN " + when.Cond.String() + " THEN " + when.Then.String())
+	}
+
+	buf.WriteString(" ELSE " + c.Else.String() + " END")
+
+	return buf.String()
+}
+
+func (c Case) Args() []any {
+	var args []any
+
+	for _, when := range c.Whens {
+		args = append(args, when.Cond.Args()...)
+		args = append(args, when.Then.Args()...)
+	}
+
+	return append(args, c.Else.Args()...)
+}
+
 // Wrapper takes an addable and wraps it.
 //
 // For example if string representation of Addable
@@ -94,6 +157,7 @@ func (n Neg) String() string {
 // `Addable.String()` to `'%' || Addable.String() || '%'".
 //
 // Wrapper defined above would look like this:
+//
 //	Wrapper{
 //		Addable: addable,
 //		StringF: func(a Addable) string {
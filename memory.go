@@ -0,0 +1,418 @@
+package goquery
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// NewMemoryFactory returns Factory of Queryable that evaluates
+// lambdas against the items in memory, by calling them
+// as regular Go functions.
+//
+// It needs neither generated code nor database, so it could
+// be used in unit tests, or as a reference implementation
+// to compare results of SQL queries against.
+//
+// Queryable of the factory share the items: Update modifies
+// them in place and Delete removes them. Base query passed
+// to New is ignored, relations are used as they are set
+// in the items, and Query panics.
+//
+// Arguments of the lambdas are not used, as lambdas read
+// the variables they capture. Those are read when the query
+// is evaluated, not when the lambda is passed, so variables
+// must not be changed in between for results to match SQL.
+func NewMemoryFactory[T any](items []T) Factory[T] {
+	checkEntityType[T]()
+
+	return &memoryFactory[T]{
+		store: &memoryStore[T]{items: slices.Clone(items)},
+	}
+}
+
+type memoryFactory[T any] struct {
+	store *memoryStore[T]
+}
+
+func (f *memoryFactory[T]) New(...*bun.SelectQuery) Queryable[T] {
+	return &memoryQueryable[T]{
+		store:  f.store,
+		source: f.store.rows,
+	}
+}
+
+type memoryStore[T any] struct {
+	mu    sync.Mutex
+	items []T
+}
+
+func (s *memoryStore[T]) rows() ([]T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.items), nil
+}
+
+// delete removes items that satisfy the filter
+// and returns number of removed items.
+//
+// Nothing is removed if the filter fails on any of the items.
+func (s *memoryStore[T]) delete(filter func(val T) (bool, error)) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	remove := make([]bool, len(s.items))
+	for i, item := range s.items {
+		ok, err := filter(item)
+		if err != nil {
+			return 0, err
+		}
+
+		remove[i] = ok
+	}
+
+	count := len(s.items)
+
+	var i int
+	s.items = slices.DeleteFunc(s.items, func(T) bool {
+		i++
+
+		return remove[i-1]
+	})
+
+	return int64(count - len(s.items)), nil
+}
+
+type memoryQueryable[T any] struct {
+	// store is nil if T is a projection.
+	store *memoryStore[T]
+	// source returns rows the query is applied to.
+	source  func() ([]T, error)
+	filters []func(val T) (bool, error)
+	orders  []memoryOrder[T]
+	// offset and limit behave as in bun,
+	// zero limit does not limit the rows.
+	offset, limit int
+}
+
+type memoryOrder[T any] struct {
+	key  func(val T) any
+	desc bool
+}
+
+func (e *memoryQueryable[T]) Where(filter func(val T) bool, _ ...any) Queryable[T] {
+	e.filters = append(e.filters, func(val T) (bool, error) {
+		return filter(val), nil
+	})
+
+	return e
+}
+
+func (e *memoryQueryable[T]) OrderBy(keySelector func(val T) any, _ ...any) Queryable[T] {
+	return e.order("OrderBy", keySelector, false)
+}
+
+func (e *memoryQueryable[T]) OrderByDescending(keySelector func(val T) any, _ ...any) Queryable[T] {
+	return e.order("OrderByDescending", keySelector, true)
+}
+
+func (e *memoryQueryable[T]) ThenBy(keySelector func(val T) any, _ ...any) Queryable[T] {
+	return e.order("ThenBy", keySelector, false)
+}
+
+func (e *memoryQueryable[T]) ThenByDescending(keySelector func(val T) any, _ ...any) Queryable[T] {
+	return e.order("ThenByDescending", keySelector, true)
+}
+
+func (e *memoryQueryable[T]) order(method string, keySelector func(val T) any, desc bool) Queryable[T] {
+	checkOrder(method, len(e.orders) != 0)
+
+	e.orders = append(e.orders, memoryOrder[T]{key: keySelector, desc: desc})
+
+	return e
+}
+
+func (e *memoryQueryable[T]) Skip(n int) Queryable[T] {
+	e.offset = n
+
+	return e
+}
+
+func (e *memoryQueryable[T]) Take(n int) Queryable[T] {
+	e.limit = n
+
+	return e
+}
+
+func (e *memoryQueryable[T]) After(cursor T) Queryable[T] {
+	if len(e.orders) == 0 {
+		panic("After must be called after OrderBy or OrderByDescending")
+	}
+
+	orders := slices.Clone(e.orders)
+	e.filters = append(e.filters, func(val T) (bool, error) {
+		res, err := compareRows(orders, val, cursor)
+
+		return res > 0, err
+	})
+
+	return e
+}
+
+func (e *memoryQueryable[T]) Include(func(val T) any) Queryable[T] {
+	return e
+}
+
+func (e *memoryQueryable[T]) ToSlice(context.Context) ([]T, error) {
+	return e.result(nil, e.limit)
+}
+
+func (e *memoryQueryable[T]) First(context.Context) (T, error) {
+	var zero T
+
+	rows, err := e.result(nil, cappedLimit(e.limit, 1))
+	if err != nil {
+		return zero, err
+	}
+
+	if len(rows) == 0 {
+		return zero, ErrNoRows
+	}
+
+	return rows[0], nil
+}
+
+func (e *memoryQueryable[T]) FirstOrDefault(ctx context.Context) (T, error) {
+	var zero T
+
+	rows, err := e.result(nil, cappedLimit(e.limit, 1))
+	if err != nil || len(rows) == 0 {
+		return zero, err
+	}
+
+	return rows[0], nil
+}
+
+func (e *memoryQueryable[T]) Single(context.Context) (T, error) {
+	var zero T
+
+	rows, err := e.result(nil, cappedLimit(e.limit, 2))
+	if err != nil {
+		return zero, err
+	}
+
+	switch len(rows) {
+	case 0:
+		return zero, ErrNoRows
+	case 1:
+		return rows[0], nil
+	default:
+		return zero, ErrMultipleRows
+	}
+}
+
+// Count counts rows of the page, if paging is set.
+func (e *memoryQueryable[T]) Count(context.Context) (int, error) {
+	rows, err := e.result(nil, e.limit)
+
+	return len(rows), err
+}
+
+func (e *memoryQueryable[T]) Any(context.Context) (bool, error) {
+	rows, err := e.result(nil, e.limit)
+
+	return len(rows) != 0, err
+}
+
+func (e *memoryQueryable[T]) All(_ context.Context, filter func(val T) bool, _ ...any) (bool, error) {
+	// Same as in SQL, rows that do not satisfy
+	// the filter are paged before the check.
+	violating, err := e.result(func(val T) bool { return !filter(val) }, e.limit)
+
+	return len(violating) == 0, err
+}
+
+func (e *memoryQueryable[T]) Update(_ context.Context, set func(val T), _ ...any) (int64, error) {
+	if e.store == nil {
+		panic("Update cannot be called on projected query")
+	}
+
+	rows, err := e.filtered(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, row := range rows {
+		set(row)
+	}
+
+	return int64(len(rows)), nil
+}
+
+func (e *memoryQueryable[T]) Delete(ctx context.Context) (int64, error) {
+	return e.delete("Delete")
+}
+
+// ForceDelete is the same as Delete, as
+// there is no soft delete for items in memory.
+func (e *memoryQueryable[T]) ForceDelete(ctx context.Context) (int64, error) {
+	return e.delete("ForceDelete")
+}
+
+func (e *memoryQueryable[T]) delete(method string) (int64, error) {
+	if e.store == nil {
+		panic(method + " cannot be called on projected query")
+	}
+
+	return e.store.delete(e.matches)
+}
+
+func (e *memoryQueryable[T]) Query() *bun.SelectQuery {
+	panic("Query is not supported by memory Queryable")
+}
+
+// matches reports whether the row satisfies all filters.
+func (e *memoryQueryable[T]) matches(val T) (bool, error) {
+	for _, filter := range e.filters {
+		if ok, err := filter(val); !ok || err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// filtered returns rows that satisfy filters of the query
+// and the additional filter, if it is set.
+func (e *memoryQueryable[T]) filtered(filter func(val T) bool) ([]T, error) {
+	rows, err := e.source()
+	if err != nil {
+		return nil, err
+	}
+
+	res := rows[:0]
+	for _, row := range rows {
+		ok, err := e.matches(row)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok && (filter == nil || filter(row)) {
+			res = append(res, row)
+		}
+	}
+
+	return res, nil
+}
+
+// result returns ordered and paged rows of the query.
+func (e *memoryQueryable[T]) result(filter func(val T) bool, limit int) ([]T, error) {
+	rows, err := e.filtered(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(e.orders) != 0 {
+		slices.SortStableFunc(rows, func(a, b T) int {
+			res, cmpErr := compareRows(e.orders, a, b)
+			if cmpErr != nil && err == nil {
+				err = cmpErr
+			}
+
+			return res
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rows = rows[min(e.offset, len(rows)):]
+	if limit > 0 && limit < len(rows) {
+		rows = rows[:limit]
+	}
+
+	return rows, nil
+}
+
+// compareRows compares rows by the ordering keys.
+func compareRows[T any](orders []memoryOrder[T], a, b T) (int, error) {
+	for _, order := range orders {
+		res, err := compareValues(order.key(a), order.key(b))
+		if err != nil {
+			return 0, err
+		}
+
+		if order.desc {
+			res = -res
+		}
+
+		if res != 0 {
+			return res, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// compareValues compares values returned from key selectors.
+//
+// Pointers are compared by the values they point to,
+// nil values are placed first, as NULLs in SQLite.
+func compareValues(a, b any) (int, error) {
+	va, vb := indirect(reflect.ValueOf(a)), indirect(reflect.ValueOf(b))
+	switch {
+	case !va.IsValid() || !vb.IsValid():
+		return cmp.Compare(boolToInt(va.IsValid()), boolToInt(vb.IsValid())), nil
+	case va.Type() != vb.Type():
+		return 0, fmt.Errorf("cannot compare values of different types %s and %s", va.Type(), vb.Type())
+	}
+
+	if ta, ok := va.Interface().(time.Time); ok {
+		return ta.Compare(vb.Interface().(time.Time)), nil
+	}
+
+	switch va.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(va.Int(), vb.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(va.Uint(), vb.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(va.Float(), vb.Float()), nil
+	case reflect.String:
+		return cmp.Compare(va.String(), vb.String()), nil
+	case reflect.Bool:
+		return cmp.Compare(boolToInt(va.Bool()), boolToInt(vb.Bool())), nil
+	default:
+		return 0, fmt.Errorf("cannot compare values of type %s", va.Type())
+	}
+}
+
+// indirect returns value the pointers point to,
+// or invalid value if any of them is nil.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+
+		v = v.Elem()
+	}
+
+	return v
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,103 @@
+package goquery
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/ffenix113/goquery/internal/sqlexpr"
+)
+
+// keysetFilter returns a filter that selects rows
+// placed after the cursor with respect to the ordering keys.
+//
+// If all keys have the same direction and rowValues is set
+// row value comparison is used, i.e. `(a, b) > (?, ?)`.
+// Otherwise filter is expanded to `a > ? OR a = ? AND b < ?`.
+//
+// Values of the cursor must not be NULL, as
+// no rows compare as placed after NULL.
+func keysetFilter[T any](keys []orderKey[T], cursor T, rowValues bool) sqlexpr.Addable {
+	columns := make([]sqlexpr.Addable, 0, len(keys))
+	values := make([]sqlexpr.Addable, 0, len(keys))
+	for i, key := range keys {
+		value := key.value(cursor)
+		if isNull(value) {
+			panic("After cursor has NULL value of ordering key " + strconv.Itoa(i) + ", rows cannot be placed after it")
+		}
+
+		// Args of the key are copied, as they are
+		// also used by ORDER BY clause of the query.
+		columns = append(columns, sqlexpr.NewSimple(key.query, slices.Clone(key.args)...))
+		values = append(values, sqlexpr.NewSimple(sqlexpr.Param, value))
+	}
+
+	if len(keys) == 1 {
+		return sqlexpr.NewBinary(columns[0], keysetOperation(keys[0].desc), values[0])
+	}
+
+	if rowValues && sameDirection(keys) {
+		return sqlexpr.NewBinary(
+			sqlexpr.Parens{Addable: sqlexpr.List(columns)},
+			keysetOperation(keys[0].desc),
+			sqlexpr.Parens{Addable: sqlexpr.List(values)},
+		)
+	}
+
+	var filter sqlexpr.Addable
+	for i, key := range keys {
+		cmp := make(sqlexpr.And, 0, i+1)
+		for j := range keys[:i] {
+			cmp = append(cmp, sqlexpr.NewBinary(columns[j], "=", values[j]))
+		}
+
+		cmp = append(cmp, sqlexpr.NewBinary(columns[i], keysetOperation(key.desc), values[i]))
+
+		if filter == nil {
+			filter = cmp
+		} else {
+			filter = sqlexpr.NewOr(filter, cmp)
+		}
+	}
+
+	return filter
+}
+
+func sameDirection[T any](keys []orderKey[T]) bool {
+	for _, key := range keys[1:] {
+		if key.desc != keys[0].desc {
+			return false
+		}
+	}
+
+	return true
+}
+
+func keysetOperation(desc bool) string {
+	if desc {
+		return "<"
+	}
+
+	return ">"
+}
+
+// isNull reports whether the value
+// would be sent to the database as NULL.
+func isNull(value any) bool {
+	if value == nil {
+		return true
+	}
+
+	if val := reflect.ValueOf(value); val.Kind() == reflect.Pointer && val.IsNil() {
+		return true
+	}
+
+	if valuer, ok := value.(driver.Valuer); ok {
+		value, err := valuer.Value()
+
+		return err == nil && value == nil
+	}
+
+	return false
+}
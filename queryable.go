@@ -1,10 +1,26 @@
 package goquery
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"runtime"
 	"strconv"
+	"strings"
 
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+var (
+	// ErrNoRows is returned when query expected
+	// to return a row does not have any.
+	//
+	// It is the same as sql.ErrNoRows.
+	ErrNoRows = sql.ErrNoRows
+	// ErrMultipleRows is returned when query expected
+	// to return single row has more than one.
+	ErrMultipleRows = errors.New("goquery: multiple rows in result set")
 )
 
 type queryable[T any] struct {
@@ -12,10 +28,46 @@ type queryable[T any] struct {
 	helper      Helper
 	db          *bun.DB
 	selectQuery *bun.SelectQuery
+	orders      []orderKey[T]
+	// filters are added to the query, and kept
+	// so they could be added to update queries.
+	filters []func(h Helper, query bun.QueryBuilder)
+	// includes add included relations to the query.
+	// bun keeps relations in the query model, so they
+	// are added only after rows are set as the model.
+	includes []func(query *bun.SelectQuery)
+	// projected is set if T is a projection of
+	// another entity, which is the query model.
+	projected bool
+	// paged is set if Skip or Take is called.
+	paged bool
+	// limit is the number of rows set by Take.
+	limit int
+	// sites are shared with queryables derived
+	// from this one, as they are chained with it.
+	sites callSites
+}
+
+// orderKey is a single ordering key of the query.
+type orderKey[T any] struct {
+	query string
+	args  []any
+	desc  bool
+	// value returns value of the key for
+	// the provided entity, it is used to
+	// generate keyset pagination filter.
+	value func(val T) any
 }
 
 func (e *queryable[T]) New(query ...*bun.SelectQuery) Queryable[T] {
 	newSet := *e
+	newSet.orders = nil
+	newSet.includes = nil
+	newSet.filters = nil
+	newSet.sites = callSites{}
+	newSet.paged = false
+	newSet.limit = 0
+	newSet.helper = scopedHelper(e.helper, false)
 
 	if len(query) > 0 {
 		newSet.selectQuery = query[0]
@@ -26,21 +78,400 @@ func (e *queryable[T]) New(query ...*bun.SelectQuery) Queryable[T] {
 	return &newSet
 }
 
-func (e *queryable[T]) Where(_ func(val T) bool, args ...any) Queryable[T] {
+func (e *queryable[T]) Where(filter func(val T) bool, args ...any) Queryable[T] {
 	// Can't out-magic the language...
 	// We still need to get the caller to fetch proper executor.
-	_, file, line, _ := runtime.Caller(1)
+	caller := e.sites.resolve(e.callsMap, "Where", getCaller())
+
+	where, ok := e.callsMap.Where[caller]
+	if !ok {
+		panicNotGenerated("Where", caller)
+	}
+
+	args = e.callsMap.captured("Where", caller, filter, args)
+
+	e.filter(func(h Helper, query bun.QueryBuilder) {
+		where(h, query, args...)
+	})
+
+	return e
+}
+
+func (e *queryable[T]) filter(filter func(h Helper, query bun.QueryBuilder)) {
+	filter(e.helper, e.selectQuery.QueryBuilder())
+	e.filters = append(e.filters, filter)
+}
+
+func (e *queryable[T]) OrderBy(keySelector func(val T) any, args ...any) Queryable[T] {
+	return e.order(getCaller(), "OrderBy", keySelector, false, args)
+}
+
+func (e *queryable[T]) OrderByDescending(keySelector func(val T) any, args ...any) Queryable[T] {
+	return e.order(getCaller(), "OrderByDescending", keySelector, true, args)
+}
+
+func (e *queryable[T]) ThenBy(keySelector func(val T) any, args ...any) Queryable[T] {
+	return e.order(getCaller(), "ThenBy", keySelector, false, args)
+}
+
+func (e *queryable[T]) ThenByDescending(keySelector func(val T) any, args ...any) Queryable[T] {
+	return e.order(getCaller(), "ThenByDescending", keySelector, true, args)
+}
+
+func (e *queryable[T]) order(caller Caller, method string, keySelector func(val T) any, desc bool, args []any) Queryable[T] {
+	caller = e.sites.resolve(e.callsMap, "OrderBy", caller)
+
+	checkOrder(method, len(e.orders) != 0)
+
+	orderBy, ok := e.callsMap.OrderBy[caller]
+	if !ok {
+		panicNotGenerated(method, caller)
+	}
+
+	key := orderKey[T]{desc: desc, value: keySelector}
+	key.query, key.args = orderBy(e.helper, e.selectQuery, e.callsMap.captured("OrderBy", caller, keySelector, args)...)
+
+	direction := " ASC"
+	if desc {
+		direction = " DESC"
+	}
+
+	e.selectQuery.OrderExpr(key.query+direction, key.args...)
+	e.orders = append(e.orders, key)
+
+	return e
+}
+
+func (e *queryable[T]) Skip(n int) Queryable[T] {
+	e.selectQuery.Offset(n)
+	e.paged = true
+
+	return e
+}
+
+func (e *queryable[T]) Take(n int) Queryable[T] {
+	e.selectQuery.Limit(n)
+	e.paged = true
+	e.limit = n
+
+	return e
+}
+
+func (e *queryable[T]) After(cursor T) Queryable[T] {
+	if len(e.orders) == 0 {
+		panic("After must be called after OrderBy or OrderByDescending")
+	}
+
+	// MSSQL does not support row value comparison.
+	filter := keysetFilter(e.orders, cursor, e.db.Dialect().Name() != dialect.MSSQL)
+	e.filter(func(_ Helper, q bun.QueryBuilder) {
+		q.Where(filter.String(), filter.Args()...)
+	})
+
+	return e
+}
+
+func (e *queryable[T]) Include(_ func(val T) any) Queryable[T] {
+	return e.include(getCaller(), "Include", nil, nil)
+}
 
-	where, ok := e.callsMap.Where[Caller{file, line}]
+// include adds the relation, filter is the lambda that
+// filters related entities, if there is one.
+func (e *queryable[T]) include(caller Caller, method string, filter any, args []any) Queryable[T] {
+	if e.projected {
+		panic(method + " cannot be called on projected query")
+	}
+
+	caller = e.sites.resolve(e.callsMap, "Include", caller)
+
+	include, ok := e.callsMap.Include[caller]
 	if !ok {
-		panic("no 'Where' function. Perhaps `go generate` was not called? caller: " + file + ":" + strconv.Itoa(line))
+		panicNotGenerated(method, caller)
+	}
+
+	if filter != nil {
+		args = e.callsMap.captured("Include", caller, filter, args)
 	}
 
-	where(e.helper, e.selectQuery, args...)
+	helper := e.helper
+	e.includes = append(e.includes, func(query *bun.SelectQuery) {
+		include(helper, query.QueryBuilder(), args...)
+	})
 
 	return e
 }
 
+func (e *queryable[T]) ToSlice(ctx context.Context) ([]T, error) {
+	return e.scan(ctx, e.terminalQuery())
+}
+
+func (e *queryable[T]) First(ctx context.Context) (T, error) {
+	rows, err := e.scan(ctx, e.terminalQuery().Limit(cappedLimit(e.limit, 1)))
+	if err == nil && len(rows) == 0 {
+		err = ErrNoRows
+	}
+
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return rows[0], nil
+}
+
+func (e *queryable[T]) FirstOrDefault(ctx context.Context) (T, error) {
+	val, err := e.First(ctx)
+	if errors.Is(err, ErrNoRows) {
+		return val, nil
+	}
+
+	return val, err
+}
+
+func (e *queryable[T]) Single(ctx context.Context) (T, error) {
+	rows, err := e.scan(ctx, e.terminalQuery().Limit(cappedLimit(e.limit, 2)))
+	if err == nil {
+		switch len(rows) {
+		case 0:
+			err = ErrNoRows
+		case 1:
+			return rows[0], nil
+		default:
+			err = ErrMultipleRows
+		}
+	}
+
+	var zero T
+	return zero, err
+}
+
+func (e *queryable[T]) Count(ctx context.Context) (int, error) {
+	var rows []T
+
+	query := e.withModel(e.terminalQuery(), &rows)
+	if !e.paged {
+		return query.Count(ctx)
+	}
+
+	// bun ignores paging when counting rows,
+	// so the page is counted as a subquery.
+	var count int
+	err := e.db.NewSelect().ColumnExpr("count(*)").TableExpr("(?) AS page", query).Scan(ctx, &count)
+
+	return count, err
+}
+
+func (e *queryable[T]) Any(ctx context.Context) (bool, error) {
+	var rows []T
+
+	return e.withModel(e.terminalQuery(), &rows).Exists(ctx)
+}
+
+func (e *queryable[T]) All(ctx context.Context, filter func(val T) bool, args ...any) (bool, error) {
+	caller := e.sites.resolve(e.callsMap, "Where", getCaller())
+
+	// Generated filter for All is negated, so
+	// it selects rows that do not satisfy it.
+	where, ok := e.callsMap.Where[caller]
+	if !ok {
+		panicNotGenerated("All", caller)
+	}
+
+	// Relations joined for the filter must not
+	// be tracked as joined for this queryable.
+	query := e.terminalQuery()
+	where(scopedHelper(e.helper, true), query.QueryBuilder(), e.callsMap.captured("Where", caller, filter, args)...)
+
+	var rows []T
+	exists, err := e.withModel(query, &rows).Exists(ctx)
+
+	return !exists, err
+}
+
+func (e *queryable[T]) Update(ctx context.Context, set func(val T), args ...any) (int64, error) {
+	caller := e.sites.resolve(e.callsMap, "Update", getCaller())
+
+	if e.projected {
+		panic("Update cannot be called on projected query")
+	}
+
+	update, ok := e.callsMap.Update[caller]
+	if !ok {
+		panicNotGenerated("Update", caller)
+	}
+
+	var model T
+	query := e.db.NewUpdate().Model(model)
+
+	helper, err := e.addFilters(query.QueryBuilder())
+	if err != nil {
+		return 0, err
+	}
+
+	update(helper, query, e.callsMap.captured("Update", caller, set, args)...)
+
+	return rowsAffected(query.Exec(ctx))
+}
+
+func (e *queryable[T]) Delete(ctx context.Context) (int64, error) {
+	return e.delete(ctx, "Delete", false)
+}
+
+func (e *queryable[T]) ForceDelete(ctx context.Context) (int64, error) {
+	return e.delete(ctx, "ForceDelete", true)
+}
+
+func (e *queryable[T]) delete(ctx context.Context, method string, force bool) (int64, error) {
+	if e.projected {
+		panic(method + " cannot be called on projected query")
+	}
+
+	var model T
+	query := e.db.NewDelete().Model(model)
+	if force {
+		query.ForceDelete()
+	}
+
+	if _, err := e.addFilters(query.QueryBuilder()); err != nil {
+		return 0, err
+	}
+
+	return rowsAffected(query.Exec(ctx))
+}
+
+// addFilters adds filters of the queryable to the
+// update or delete query and returns the helper
+// that should be used for the rest of the query.
+//
+// Relations can be joined only to select queries, so if
+// filters navigate relations, rows are matched by primary
+// key to the rows of select query with the filters.
+func (e *queryable[T]) addFilters(query bun.QueryBuilder) (Helper, error) {
+	var model T
+	selectQuery := e.db.NewSelect().Model(model)
+
+	joined := scopedHelper(e.helper, false)
+	for _, filter := range e.filters {
+		filter(joined, selectQuery.QueryBuilder())
+	}
+
+	// Columns of the query must not be
+	// qualified with the joined relations.
+	helper := scopedHelper(e.helper, false)
+
+	base, ok := asBunHelper(joined)
+	if !ok || len(base.joins) == 0 {
+		for _, filter := range e.filters {
+			filter(helper, query)
+		}
+
+		return helper, nil
+	}
+
+	if len(base.table.PKs) == 0 {
+		return nil, errors.New("goquery: relations cannot be used in filters of " + base.table.TypeName + " without primary key")
+	}
+
+	pks := make([]any, 0, len(base.table.PKs))
+	qualifiedPKs := make([]any, 0, len(base.table.PKs))
+	for _, pk := range base.table.PKs {
+		pks = append(pks, pk.SQLName)
+		qualifiedPKs = append(qualifiedPKs, bun.Ident(base.table.Alias+"."+pk.Name))
+	}
+
+	// Soft deleted rows are filtered by the query itself.
+	if base.table.SoftDeleteField != nil {
+		selectQuery.WhereAllWithDeleted()
+	}
+
+	list := strings.TrimSuffix(strings.Repeat("?, ", len(pks)), ", ")
+	selectQuery.ColumnExpr(list, qualifiedPKs...)
+	query.Where("("+list+") IN (?)", append(pks, selectQuery)...)
+
+	return helper, nil
+}
+
+func rowsAffected(res sql.Result, err error) (int64, error) {
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+func (e *queryable[T]) scan(ctx context.Context, query *bun.SelectQuery) ([]T, error) {
+	var rows []T
+
+	var err error
+	if e.projected {
+		err = query.Scan(ctx, &rows)
+	} else {
+		query.Model(&rows)
+		for _, include := range e.includes {
+			include(query)
+		}
+
+		err = query.Scan(ctx)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// withModel sets rows as query model, unless T is
+// a projection, for which the model is already set.
+func (e *queryable[T]) withModel(query *bun.SelectQuery, rows *[]T) *bun.SelectQuery {
+	if e.projected {
+		return query
+	}
+
+	return query.Model(rows)
+}
+
+// cappedLimit returns limit of the query which needs at most
+// n rows, so the limit set by Take is not exceeded.
+func cappedLimit(limit, n int) int {
+	if limit > 0 {
+		return min(limit, n)
+	}
+
+	return n
+}
+
+// terminalQuery returns a copy of the select query,
+// so executing the query would not modify this queryable.
+func (e *queryable[T]) terminalQuery() *bun.SelectQuery {
+	return e.selectQuery.Clone()
+}
+
 func (e *queryable[T]) Query() *bun.SelectQuery {
 	return e.selectQuery
 }
+
+// checkOrder panics if ordering method
+// is called in the wrong order.
+func checkOrder(method string, ordered bool) {
+	isThen := method == "ThenBy" || method == "ThenByDescending"
+	switch {
+	case isThen && !ordered:
+		panic(method + " must be called after OrderBy or OrderByDescending")
+	case !isThen && ordered:
+		panic(method + " cannot be called on already ordered query, use ThenBy or ThenByDescending instead")
+	}
+}
+
+// getCaller returns position from which
+// queryable method was called.
+func getCaller() Caller {
+	// Skip getCaller itself and the queryable method.
+	_, file, line, _ := runtime.Caller(2)
+
+	return Caller{File: callerFile(file), Line: line}
+}
+
+func panicNotGenerated(method string, caller Caller) {
+	panic("no '" + method + "' function. Perhaps `go generate` was not called? caller: " + caller.File + ":" + strconv.Itoa(caller.Line))
+}
@@ -0,0 +1,147 @@
+package goquery
+
+import (
+	"strings"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+)
+
+// RelationInfo describes how to query related entity.
+type RelationInfo struct {
+	// Helper is a helper of the related entity.
+	// Its column names are qualified with the table alias.
+	Helper Helper
+	// From is a table expression of the related entity.
+	From schema.QueryAppender
+	// Condition correlates rows of the related
+	// entity with the rows of the base entity.
+	Condition schema.QueryAppender
+}
+
+// DO NOT USE: this is only for generated code!
+//
+// Relation returns relation of the entity
+// by the name of the relation field.
+func Relation(h Helper, fieldName string) RelationInfo {
+	base, rel := getRelation(h, fieldName)
+
+	baseAlias := base.tableAlias()
+
+	joinHelper := newBunHelper(rel.JoinTable)
+	joinHelper.alias = rel.JoinTable.Alias
+	if joinHelper.alias == baseAlias {
+		// Self-referencing relation must use
+		// different alias for the related table.
+		joinHelper.alias = baseAlias + "__" + rel.Field.Name
+	}
+
+	info := RelationInfo{
+		Helper: joinHelper,
+		From:   schema.SafeQuery("? AS ?", []any{rel.JoinTable.SQLName, bun.Ident(joinHelper.alias)}),
+	}
+
+	if rel.Type != schema.ManyToManyRelation {
+		info.Condition = fieldsEqual(joinHelper.alias, rel.JoinPKs, baseAlias, rel.BasePKs)
+
+		return info
+	}
+
+	m2mAlias := rel.M2MTable.Alias
+	info.From = schema.SafeQuery("? JOIN ? AS ? ON ?", []any{
+		info.From,
+		rel.M2MTable.SQLName, bun.Ident(m2mAlias),
+		fieldsEqual(m2mAlias, rel.M2MJoinPKs, joinHelper.alias, rel.JoinPKs),
+	})
+	info.Condition = fieldsEqual(m2mAlias, rel.M2MBasePKs, baseAlias, rel.BasePKs)
+
+	return info
+}
+
+// DO NOT USE: this is only for generated code!
+//
+// Qualified returns helper which qualifies
+// column names with the table alias.
+func Qualified(h Helper) Helper {
+	base, ok := asBunHelper(h)
+	if !ok {
+		panic("relations are supported only with helper created by NewBunHelper")
+	}
+
+	base.alias = base.tableAlias()
+
+	return base
+}
+
+// DO NOT USE: this is only for generated code!
+//
+// Join joins has-one or belongs-to relation to the query,
+// if it is not joined yet, and returns helper of the
+// related entity. Its column names are qualified
+// with the alias of the joined table.
+func Join(h Helper, query bun.QueryBuilder, fieldName string) Helper {
+	base, rel := getRelation(h, fieldName)
+
+	selectQuery, ok := query.Unwrap().(*bun.SelectQuery)
+	if !ok {
+		panic("relations can be joined only to select queries: " + fieldName)
+	}
+
+	if rel.Type != schema.HasOneRelation && rel.Type != schema.BelongsToRelation {
+		panic("only has-one and belongs-to relations can be joined: " + fieldName)
+	}
+
+	if base.joins == nil {
+		panic("relations can not be joined with this helper: " + fieldName)
+	}
+
+	baseAlias := base.tableAlias()
+	// Alias is different from the one bun uses for
+	// relations, so they would not conflict.
+	alias := baseAlias + "__" + rel.Field.Name
+
+	if !base.joins[alias] {
+		selectQuery.Join("LEFT JOIN ? AS ? ON ?",
+			rel.JoinTable.SQLName, bun.Ident(alias),
+			fieldsEqual(alias, rel.JoinPKs, baseAlias, rel.BasePKs),
+		)
+		base.joins[alias] = true
+	}
+
+	joinHelper := newBunHelper(rel.JoinTable)
+	joinHelper.alias = alias
+	joinHelper.joins = base.joins
+
+	return joinHelper
+}
+
+func getRelation(h Helper, fieldName string) (bunHelper, *schema.Relation) {
+	base, ok := asBunHelper(h)
+	if !ok {
+		panic("relations are supported only with helper created by NewBunHelper")
+	}
+
+	rel, ok := base.table.Relations[fieldName]
+	if !ok {
+		panic("unknown relation: " + fieldName)
+	}
+
+	return base, rel
+}
+
+// fieldsEqual returns condition that checks
+// that fields of two tables are equal.
+func fieldsEqual(leftAlias string, leftFields []*schema.Field, rightAlias string, rightFields []*schema.Field) schema.QueryAppender {
+	conditions := make([]string, 0, len(leftFields))
+	args := make([]any, 0, len(leftFields)*4)
+
+	for i := range leftFields {
+		conditions = append(conditions, "?.? = ?.?")
+		args = append(args,
+			bun.Ident(leftAlias), leftFields[i].SQLName,
+			bun.Ident(rightAlias), rightFields[i].SQLName,
+		)
+	}
+
+	return schema.SafeQuery(strings.Join(conditions, " AND "), args)
+}
@@ -0,0 +1,69 @@
+package goquery
+
+import (
+	"reflect"
+)
+
+// Select projects each row of the query into a new form.
+//
+// The selector must return a struct literal(or a pointer to it).
+// Each field of the literal becomes a column named after
+// the field of R, so only the needed columns are fetched:
+//
+//	goquery.Select(q, func(u *User) UserDTO {
+//		return UserDTO{Name: strings.ToUpper(u.Name), Total: u.Price * u.Qty}
+//	})
+//
+// The query is modified and should only be used
+// through the returned Queryable afterwards.
+func Select[T, R any](q Queryable[T], selector func(val T) R, args ...any) Queryable[R] {
+	if source, ok := q.(*memoryQueryable[T]); ok {
+		return &memoryQueryable[R]{
+			source: func() ([]R, error) {
+				rows, err := source.result(nil, source.limit)
+				if err != nil {
+					return nil, err
+				}
+
+				results := make([]R, 0, len(rows))
+				for _, row := range rows {
+					results = append(results, selector(row))
+				}
+
+				return results, nil
+			},
+		}
+	}
+
+	caller := getCaller()
+
+	source, ok := q.(*queryable[T])
+	if !ok {
+		panic("Select is not supported for " + reflect.TypeOf(q).String())
+	}
+
+	caller = source.sites.resolve(source.callsMap, "Select", caller)
+
+	project, ok := source.callsMap.Select[caller]
+	if !ok {
+		panicNotGenerated("Select", caller)
+	}
+
+	if !source.projected {
+		var model T
+		source.selectQuery.Model(model)
+	}
+
+	resultHelper := NewBunHelper[R](source.db)
+	project(source.helper, resultHelper, source.selectQuery, source.callsMap.captured("Select", caller, selector, args)...)
+
+	return &queryable[R]{
+		callsMap:    globalCallsMap[entityType[R]()],
+		helper:      resultHelper,
+		db:          source.db,
+		selectQuery: source.selectQuery,
+		projected:   true,
+		// Calls are counted per entity.
+		sites: callSites{},
+	}
+}